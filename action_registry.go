@@ -0,0 +1,73 @@
+package goops
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RegisterAction registers a typed handler for the named action on the dispatcher.
+// The action parameters are validated against the action's schema in actions.yaml
+// or charmcraft.yaml, completed with their defaults and decoded into P through
+// their json tags. When validation or the handler fails, the action is failed with
+// FailActionf and the hook itself succeeds, as Juju expects. Otherwise the results
+// returned by the handler are set with SetActionResults.
+func RegisterAction[P any](d *Dispatcher, name string, handler func(params P) (map[string]string, error)) {
+	d.OnAction(name, func(Event) error {
+		return runAction(d.client, name, handler)
+	})
+}
+
+func runAction[P any](client *Client, name string, handler func(params P) (map[string]string, error)) error {
+	var params P
+
+	err := client.getValidatedActionParams(name, &params)
+	if err != nil {
+		return client.FailActionf("%s", err.Error())
+	}
+
+	results, err := handler(params)
+	if err != nil {
+		return client.FailActionf("%s", err.Error())
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return client.SetActionResults(results)
+}
+
+// getValidatedActionParams retrieves the parameters of the action, validates them
+// against its schema and unmarshals them into params.
+func (c *Client) getValidatedActionParams(name string, params any) error {
+	var raw map[string]any
+
+	err := c.GetActionParams(&raw)
+	if err != nil {
+		return err
+	}
+
+	schema, err := c.ReadActionsSchema()
+	if err != nil {
+		return err
+	}
+
+	if actionSchema, ok := schema[name]; ok {
+		raw, err = actionSchema.Validate(name, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode action parameters: %w", err)
+	}
+
+	err = json.Unmarshal(encoded, params)
+	if err != nil {
+		return fmt.Errorf("failed to parse action parameters: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package goops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// actionResultKeyPattern is the pattern Juju enforces on each dot-separated part of an action-set key.
+var actionResultKeyPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$`)
+
+// reservedActionResultKeys are top-level keys Juju uses for the output of the action itself.
+var reservedActionResultKeys = []string{"stdout", "stdout-encoding", "stderr", "stderr-encoding"}
+
+// validateActionResultKey checks a key against the naming rules of action-set.
+// Keys are made of dot-separated parts, each made of lowercase letters, digits
+// and hyphens, starting and ending with a letter or digit.
+func validateActionResultKey(key string) error {
+	for _, part := range strings.Split(key, ".") {
+		if !actionResultKeyPattern.MatchString(part) {
+			return fmt.Errorf("invalid action result key %q: %q must contain only lowercase letters, digits and hyphens, and start and end with a letter or digit", key, part)
+		}
+	}
+
+	top, _, _ := strings.Cut(key, ".")
+	for _, reserved := range reservedActionResultKeys {
+		if top == reserved {
+			return fmt.Errorf("invalid action result key %q: %q is reserved by Juju", key, reserved)
+		}
+	}
+
+	return nil
+}
+
+// SetActionResultsFrom sets action results from a struct, map or slice.
+// The value is encoded as JSON and flattened into dotted keys that Juju renders
+// as nested maps: struct fields are named after their json tags, slice elements
+// after their index, and numbers and booleans are formatted as strings. Null
+// values are skipped. Every key is checked against Juju's naming rules before
+// action-set is called.
+// This functionality only works when the charm is running in an action hook.
+func (c *Client) SetActionResultsFrom(v any) error {
+	results, err := flattenActionResults(v)
+	if err != nil {
+		return err
+	}
+
+	return c.SetActionResults(results)
+}
+
+// SetActionResultsFrom sets action results from a struct, map or slice.
+// The value is encoded as JSON and flattened into dotted keys that Juju renders
+// as nested maps: struct fields are named after their json tags, slice elements
+// after their index, and numbers and booleans are formatted as strings. Null
+// values are skipped. Every key is checked against Juju's naming rules before
+// action-set is called.
+// This functionality only works when the charm is running in an action hook.
+func SetActionResultsFrom(v any) error {
+	return defaultClient.SetActionResultsFrom(v)
+}
+
+// flattenActionResults converts a struct, map or slice into the dotted keys used by SetActionResultsFrom.
+func flattenActionResults(v any) (map[string]string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode action results: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var decoded any
+
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode action results: %w", err)
+	}
+
+	switch decoded.(type) {
+	case map[string]any, []any:
+	default:
+		return nil, fmt.Errorf("action results must be a struct, map or slice, got %T", v)
+	}
+
+	results := make(map[string]string)
+
+	flattenActionResult("", decoded, results)
+
+	return results, nil
+}
+
+func flattenActionResult(key string, value any, results map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, child := range v {
+			flattenActionResult(joinActionResultKey(key, name), child, results)
+		}
+	case []any:
+		for i, child := range v {
+			flattenActionResult(joinActionResultKey(key, strconv.Itoa(i)), child, results)
+		}
+	case string:
+		results[key] = v
+	case json.Number:
+		results[key] = v.String()
+	case bool:
+		results[key] = strconv.FormatBool(v)
+	}
+}
+
+func joinActionResultKey(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// sortedActionResultArgs returns the key=value arguments of action-set, in key order.
+func sortedActionResultArgs(results map[string]string) ([]string, error) {
+	keys := make([]string, 0, len(results))
+
+	for key := range results {
+		err := validateActionResultKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key+"="+results[key])
+	}
+
+	return args, nil
+}
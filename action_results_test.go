@@ -0,0 +1,94 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+type exampleActionResults struct {
+	CACertificate string   `json:"ca-certificate"`
+	Chain         []string `json:"chain"`
+	Validity      struct {
+		Days    int  `json:"days"`
+		Expired bool `json:"expired"`
+	} `json:"validity"`
+	Comment *string `json:"comment"`
+}
+
+func TestSetActionResultsFrom_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	results := exampleActionResults{
+		CACertificate: "ca",
+		Chain:         []string{"intermediate", "root"},
+	}
+	results.Validity.Days = 90
+
+	err := goops.SetActionResultsFrom(results)
+	if err != nil {
+		t.Fatalf("SetActionResultsFrom returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "action-set" {
+		t.Errorf("Expected command %q, got %q", "action-set", fakeRunner.Command)
+	}
+
+	expected := []string{
+		"ca-certificate=ca",
+		"chain.0=intermediate",
+		"chain.1=root",
+		"validity.days=90",
+		"validity.expired=false",
+	}
+
+	if len(fakeRunner.Args) != len(expected) {
+		t.Fatalf("Expected arguments %v, got %v", expected, fakeRunner.Args)
+	}
+
+	for i, arg := range expected {
+		if fakeRunner.Args[i] != arg {
+			t.Errorf("Expected argument %q, got %q", arg, fakeRunner.Args[i])
+		}
+	}
+}
+
+func TestSetActionResultsFrom_InvalidKey(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	tests := []struct {
+		name    string
+		results any
+	}{
+		{name: "Uppercase", results: map[string]string{"CACertificate": "ca"}},
+		{name: "Underscore", results: map[string]string{"ca_certificate": "ca"}},
+		{name: "TrailingHyphen", results: map[string]string{"ca-": "ca"}},
+		{name: "Reserved", results: map[string]string{"stdout": "ca"}},
+		{name: "NotAnObject", results: "ca"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRunner.Command = ""
+
+			err := goops.SetActionResultsFrom(tc.results)
+			if err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+
+			if fakeRunner.Command != "" {
+				t.Errorf("Expected action-set not to be called, got %q", fakeRunner.Command)
+			}
+		})
+	}
+}
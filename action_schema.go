@@ -0,0 +1,203 @@
+package goops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ActionParam describes an action parameter, using the JSON schema subset supported by Juju.
+type ActionParam struct {
+	Type        string `yaml:"type,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Default     any    `yaml:"default,omitempty"`
+	Enum        []any  `yaml:"enum,omitempty"`
+}
+
+// ActionSchema describes an action declared by the charm.
+type ActionSchema struct {
+	Description          string                 `yaml:"description,omitempty"`
+	Params               map[string]ActionParam `yaml:"params,omitempty"`
+	Required             []string               `yaml:"required,omitempty"`
+	AdditionalProperties *bool                  `yaml:"additionalProperties,omitempty"`
+}
+
+// ActionsSchema maps action names to their schema, as declared in the charm's
+// actions.yaml or in the actions section of its charmcraft.yaml.
+type ActionsSchema map[string]ActionSchema
+
+// ActionParamsError lists the parameters of an action that do not satisfy its schema.
+type ActionParamsError struct {
+	Action      string
+	InvalidKeys []InvalidKey
+}
+
+func (e *ActionParamsError) Error() string {
+	keys := make([]string, 0, len(e.InvalidKeys))
+	for _, invalidKey := range e.InvalidKeys {
+		keys = append(keys, fmt.Sprintf("%s: %s", invalidKey.Key, invalidKey.Reason))
+	}
+
+	return fmt.Sprintf("invalid parameters for action %s: %s", e.Action, strings.Join(keys, "; "))
+}
+
+// ParseActionsSchema parses the content of an actions.yaml file.
+// The content of a charmcraft.yaml file is also accepted, in which case the
+// actions are read from its actions section.
+func ParseActionsSchema(data []byte) (ActionsSchema, error) {
+	var charmcraft struct {
+		Name    string        `yaml:"name"`
+		Actions ActionsSchema `yaml:"actions"`
+	}
+
+	err := yaml.Unmarshal(data, &charmcraft)
+	if err == nil && charmcraft.Name != "" {
+		return nonNilActionsSchema(charmcraft.Actions), nil
+	}
+
+	var schema ActionsSchema
+
+	err = yaml.Unmarshal(data, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal actions schema: %w", err)
+	}
+
+	return nonNilActionsSchema(schema), nil
+}
+
+func nonNilActionsSchema(schema ActionsSchema) ActionsSchema {
+	if schema == nil {
+		return make(ActionsSchema)
+	}
+
+	return schema
+}
+
+// ReadActionsSchema reads the actions of the charm from actions.yaml in the charm
+// directory, falling back to the actions section of charmcraft.yaml.
+func (c *Client) ReadActionsSchema() (ActionsSchema, error) {
+	data, err := c.readCharmFile("actions.yaml", "charmcraft.yaml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(ActionsSchema), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read actions schema: %w", err)
+	}
+
+	return ParseActionsSchema(data)
+}
+
+// ReadActionsSchema reads the actions of the charm from actions.yaml in the charm
+// directory, falling back to the actions section of charmcraft.yaml.
+func ReadActionsSchema() (ActionsSchema, error) {
+	return defaultClient.ReadActionsSchema()
+}
+
+// Validate checks action parameters against the schema and returns them with
+// defaults applied to the parameters that were not set.
+// Required parameters, types, enums and, when additionalProperties is false,
+// unknown parameters are checked. A *ActionParamsError listing every invalid
+// parameter is returned.
+func (s ActionSchema) Validate(action string, params map[string]any) (map[string]any, error) {
+	validated := make(map[string]any, len(params))
+	for key, value := range params {
+		validated[key] = value
+	}
+
+	for name, param := range s.Params {
+		if _, ok := validated[name]; !ok && param.Default != nil {
+			validated[name] = param.Default
+		}
+	}
+
+	var invalidKeys []InvalidKey
+
+	for _, name := range s.Required {
+		if _, ok := validated[name]; !ok {
+			invalidKeys = append(invalidKeys, InvalidKey{Key: name, Reason: "required parameter is missing"})
+		}
+	}
+
+	for name, value := range validated {
+		param, ok := s.Params[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				invalidKeys = append(invalidKeys, InvalidKey{Key: name, Reason: "unknown parameter"})
+			}
+
+			continue
+		}
+
+		if param.Type != "" && !jsonSchemaTypeMatches(param.Type, value) {
+			invalidKeys = append(invalidKeys, InvalidKey{
+				Key:    name,
+				Reason: fmt.Sprintf("expected %s, got %T", param.Type, value),
+			})
+
+			continue
+		}
+
+		if len(param.Enum) > 0 && !enumContains(param.Enum, value) {
+			invalidKeys = append(invalidKeys, InvalidKey{
+				Key:    name,
+				Reason: fmt.Sprintf("value %v is not one of %v", value, param.Enum),
+			})
+		}
+	}
+
+	if len(invalidKeys) > 0 {
+		sort.SliceStable(invalidKeys, func(i, j int) bool {
+			return invalidKeys[i].Key < invalidKeys[j].Key
+		})
+
+		return nil, &ActionParamsError{Action: action, InvalidKeys: invalidKeys}
+	}
+
+	return validated, nil
+}
+
+// jsonSchemaTypeMatches reports whether a value decoded from JSON or YAML has the given JSON schema type.
+func jsonSchemaTypeMatches(schemaType string, value any) bool {
+	switch v := value.(type) {
+	case string:
+		return schemaType == "string"
+	case bool:
+		return schemaType == "boolean"
+	case int, int64, uint64:
+		return schemaType == "integer" || schemaType == "number"
+	case float64:
+		return schemaType == "number" || (schemaType == "integer" && v == float64(int64(v)))
+	case []any:
+		return schemaType == "array"
+	case map[string]any:
+		return schemaType == "object"
+	case nil:
+		return schemaType == "null"
+	default:
+		return false
+	}
+}
+
+// enumContains compares values through their JSON encoding, so that numbers
+// decoded from YAML and from JSON compare equal.
+func enumContains(enum []any, value any) bool {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range enum {
+		encodedAllowed, err := json.Marshal(allowed)
+		if err == nil && string(encodedAllowed) == string(encodedValue) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+const exampleCharmcraftActions = `
+name: example
+actions:
+  rotate-certificate:
+    description: Rotates the certificate
+    params:
+      validity-days:
+        type: integer
+        default: 90
+      algorithm:
+        type: string
+        enum: [rsa, ecdsa]
+      force:
+        type: boolean
+    required: [algorithm]
+    additionalProperties: false
+`
+
+func TestParseActionsSchema_Charmcraft(t *testing.T) {
+	schema, err := goops.ParseActionsSchema([]byte(exampleCharmcraftActions))
+	if err != nil {
+		t.Fatalf("ParseActionsSchema returned an error: %v", err)
+	}
+
+	action, ok := schema["rotate-certificate"]
+	if !ok {
+		t.Fatalf("Expected rotate-certificate action, got %v", schema)
+	}
+
+	if len(action.Params) != 3 {
+		t.Errorf("Expected 3 parameters, got %d", len(action.Params))
+	}
+
+	if len(action.Required) != 1 || action.Required[0] != "algorithm" {
+		t.Errorf("Unexpected required parameters: %v", action.Required)
+	}
+}
+
+func TestParseActionsSchema_ActionsYAML(t *testing.T) {
+	schema, err := goops.ParseActionsSchema([]byte(`
+get-ca-certificate:
+  description: Outputs the CA cert
+`))
+	if err != nil {
+		t.Fatalf("ParseActionsSchema returned an error: %v", err)
+	}
+
+	if schema["get-ca-certificate"].Description != "Outputs the CA cert" {
+		t.Errorf("Unexpected schema: %v", schema)
+	}
+}
+
+func TestActionSchemaValidate(t *testing.T) {
+	schema, err := goops.ParseActionsSchema([]byte(exampleCharmcraftActions))
+	if err != nil {
+		t.Fatalf("ParseActionsSchema returned an error: %v", err)
+	}
+
+	action := schema["rotate-certificate"]
+
+	params, err := action.Validate("rotate-certificate", map[string]any{"algorithm": "rsa"})
+	if err != nil {
+		t.Fatalf("Validate returned an error: %v", err)
+	}
+
+	if params["validity-days"] != 90 {
+		t.Errorf("Expected default validity-days 90, got %v", params["validity-days"])
+	}
+
+	_, err = action.Validate("rotate-certificate", map[string]any{
+		"validity-days": 1.5,
+		"algorithm":     "dsa",
+		"unknown":       true,
+	})
+
+	var paramsErr *goops.ActionParamsError
+	if !errors.As(err, &paramsErr) {
+		t.Fatalf("Expected an ActionParamsError, got %v", err)
+	}
+
+	expected := []goops.InvalidKey{
+		{Key: "algorithm", Reason: "value dsa is not one of [rsa ecdsa]"},
+		{Key: "unknown", Reason: "unknown parameter"},
+		{Key: "validity-days", Reason: "expected integer, got float64"},
+	}
+
+	if len(paramsErr.InvalidKeys) != len(expected) {
+		t.Fatalf("Expected invalid keys %v, got %v", expected, paramsErr.InvalidKeys)
+	}
+
+	for i, invalidKey := range expected {
+		if paramsErr.InvalidKeys[i] != invalidKey {
+			t.Errorf("Expected invalid key %v, got %v", invalidKey, paramsErr.InvalidKeys[i])
+		}
+	}
+}
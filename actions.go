@@ -14,8 +14,8 @@ const (
 
 // FailActionf fails the current action with a formatted message.
 // This functionality only works when the charm is running in an action hook.
-func FailActionf(format string, args ...any) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) FailActionf(format string, args ...any) error {
+	commandRunner := c.commandRunner()
 
 	message := fmt.Sprintf(format, args...)
 
@@ -27,10 +27,16 @@ func FailActionf(format string, args ...any) error {
 	return nil
 }
 
+// FailActionf fails the current action with a formatted message.
+// This functionality only works when the charm is running in an action hook.
+func FailActionf(format string, args ...any) error {
+	return defaultClient.FailActionf(format, args...)
+}
+
 // GetActionParams retrieves the parameters for the current action and unmarshals them into the provided params struct.
 // This functionality only works when the charm is running in an action hook.
-func GetActionParams(params any) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetActionParams(params any) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--format=json"}
 
@@ -47,10 +53,16 @@ func GetActionParams(params any) error {
 	return nil
 }
 
+// GetActionParams retrieves the parameters for the current action and unmarshals them into the provided params struct.
+// This functionality only works when the charm is running in an action hook.
+func GetActionParams(params any) error {
+	return defaultClient.GetActionParams(params)
+}
+
 // ActionLogf records a progress message for the current action.
 // This functionality only works when the charm is running in an action hook.
-func ActionLogf(format string, args ...any) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) ActionLogf(format string, args ...any) error {
+	commandRunner := c.commandRunner()
 
 	message := fmt.Sprintf(format, args...)
 
@@ -62,21 +74,34 @@ func ActionLogf(format string, args ...any) error {
 	return nil
 }
 
-// SetActionResults sets action results.
+// ActionLogf records a progress message for the current action.
 // This functionality only works when the charm is running in an action hook.
-func SetActionResults(results map[string]string) error {
-	commandRunner := GetCommandRunner()
+func ActionLogf(format string, args ...any) error {
+	return defaultClient.ActionLogf(format, args...)
+}
 
-	var args []string
+// SetActionResults sets action results.
+// Keys can be dotted to set nested results and must follow Juju's naming rules.
+// This functionality only works when the charm is running in an action hook.
+func (c *Client) SetActionResults(results map[string]string) error {
+	commandRunner := c.commandRunner()
 
-	for key, value := range results {
-		args = append(args, key+"="+value)
+	args, err := sortedActionResultArgs(results)
+	if err != nil {
+		return err
 	}
 
-	_, err := commandRunner.Run(actionSetCommand, args...)
+	_, err = commandRunner.Run(actionSetCommand, args...)
 	if err != nil {
 		return fmt.Errorf("failed to set action parameters: %w", err)
 	}
 
 	return nil
 }
+
+// SetActionResults sets action results.
+// Keys can be dotted to set nested results and must follow Juju's naming rules.
+// This functionality only works when the charm is running in an action hook.
+func SetActionResults(results map[string]string) error {
+	return defaultClient.SetActionResults(results)
+}
@@ -8,8 +8,8 @@ const (
 
 // SetAppVersion sets the application version.
 // The version set will be displayed in “juju status” output for the application.
-func SetAppVersion(version string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetAppVersion(version string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{}
 	if version != "" {
@@ -23,3 +23,9 @@ func SetAppVersion(version string) error {
 
 	return nil
 }
+
+// SetAppVersion sets the application version.
+// The version set will be displayed in “juju status” output for the application.
+func SetAppVersion(version string) error {
+	return defaultClient.SetAppVersion(version)
+}
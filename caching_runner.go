@@ -0,0 +1,113 @@
+package goops
+
+import (
+	"strings"
+	"sync"
+)
+
+// cachedTools lists the read-only hook tools whose results are cached.
+var cachedTools = map[string]bool{
+	"config-get":      true,
+	"goal-state":      true,
+	"is-leader":       true,
+	"network-get":     true,
+	"relation-get":    true,
+	"relation-ids":    true,
+	"relation-list":   true,
+	"secret-info-get": true,
+	"state-get":       true,
+}
+
+// invalidatingTools maps write hook tools to the cached tools whose results they change.
+var invalidatingTools = map[string][]string{
+	"relation-set":  {"relation-get"},
+	"state-set":     {"state-get"},
+	"state-delete":  {"state-get"},
+	"secret-set":    {"secret-info-get"},
+	"secret-remove": {"secret-info-get"},
+	"secret-grant":  {"secret-info-get"},
+	"secret-revoke": {"secret-info-get"},
+}
+
+type cachingCommandRunner struct {
+	runner CommandRunner
+
+	mu    sync.Mutex
+	cache map[string][]byte
+}
+
+// NewCachingCommandRunner wraps runner so that the results of read-only hook tools
+// (config-get, is-leader, relation-ids, relation-get, relation-list, goal-state,
+// network-get, state-get and secret-info-get) are remembered and returned again for
+// identical calls. Calling a write tool forgets the results it may change, for example
+// relation-set forgets every relation-get result. Failed calls are not cached, and
+// is-leader is only cached once it reports leadership, since a unit can gain
+// leadership during a hook but does not lose it before the hook ends.
+// The data returned by these tools does not change during a hook unless the charm
+// changes it, so the runner is meant to live for a single hook.
+func NewCachingCommandRunner(runner CommandRunner) CommandRunner {
+	return &cachingCommandRunner{
+		runner: runner,
+		cache:  make(map[string][]byte),
+	}
+}
+
+func (r *cachingCommandRunner) Run(name string, args ...string) ([]byte, error) {
+	if !cachedTools[name] {
+		r.invalidate(name)
+
+		return r.runner.Run(name, args...)
+	}
+
+	key := cacheKey(name, args)
+
+	r.mu.Lock()
+	output, ok := r.cache[key]
+	r.mu.Unlock()
+
+	if ok {
+		return append([]byte(nil), output...), nil
+	}
+
+	output, err := r.runner.Run(name, args...)
+	if err != nil {
+		return output, err
+	}
+
+	if name == "is-leader" && !isLeaderOutput(output) {
+		return output, nil
+	}
+
+	r.mu.Lock()
+	r.cache[key] = append([]byte(nil), output...)
+	r.mu.Unlock()
+
+	return output, nil
+}
+
+func (r *cachingCommandRunner) invalidate(name string) {
+	tools, ok := invalidatingTools[name]
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.cache {
+		for _, tool := range tools {
+			if strings.HasPrefix(key, tool+"\x00") {
+				delete(r.cache, key)
+			}
+		}
+	}
+}
+
+// isLeaderOutput reports whether the output of is-leader says the unit is the leader.
+func isLeaderOutput(output []byte) bool {
+	return strings.EqualFold(strings.TrimSpace(string(output)), "true")
+}
+
+func cacheKey(name string, args []string) string {
+	return name + "\x00" + strings.Join(args, "\x00")
+}
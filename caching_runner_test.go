@@ -0,0 +1,126 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+// CountingRunner returns a fixed output and counts the calls made to each hook tool.
+type CountingRunner struct {
+	Output []byte
+	Calls  map[string]int
+}
+
+func (r *CountingRunner) Run(name string, args ...string) ([]byte, error) {
+	if r.Calls == nil {
+		r.Calls = make(map[string]int)
+	}
+
+	r.Calls[name]++
+
+	return r.Output, nil
+}
+
+func TestCachingCommandRunner_CachesReadOnlyTools(t *testing.T) {
+	countingRunner := &CountingRunner{Output: []byte(`true`)}
+
+	client := goops.NewClient(goops.WithCommandRunner(countingRunner), goops.WithHookToolCache())
+
+	for range 3 {
+		isLeader, err := client.IsLeader()
+		if err != nil {
+			t.Fatalf("IsLeader returned an error: %v", err)
+		}
+
+		if !isLeader {
+			t.Errorf("Expected unit to be leader")
+		}
+	}
+
+	if countingRunner.Calls["is-leader"] != 1 {
+		t.Errorf("Expected is-leader to run once, ran %d times", countingRunner.Calls["is-leader"])
+	}
+}
+
+// SequenceRunner returns its outputs in order, one per call.
+type SequenceRunner struct {
+	Outputs [][]byte
+	Calls   int
+}
+
+func (r *SequenceRunner) Run(name string, args ...string) ([]byte, error) {
+	output := r.Outputs[min(r.Calls, len(r.Outputs)-1)]
+	r.Calls++
+
+	return output, nil
+}
+
+func TestCachingCommandRunner_DoesNotCacheNonLeader(t *testing.T) {
+	sequenceRunner := &SequenceRunner{Outputs: [][]byte{[]byte(`false`), []byte(`true`)}}
+
+	client := goops.NewClient(goops.WithCommandRunner(sequenceRunner), goops.WithHookToolCache())
+
+	expected := []bool{false, true, true}
+
+	for i, want := range expected {
+		isLeader, err := client.IsLeader()
+		if err != nil {
+			t.Fatalf("IsLeader returned an error: %v", err)
+		}
+
+		if isLeader != want {
+			t.Errorf("Expected call %d to return %v, got %v", i+1, want, isLeader)
+		}
+	}
+
+	if sequenceRunner.Calls != 2 {
+		t.Errorf("Expected is-leader to run twice, ran %d times", sequenceRunner.Calls)
+	}
+}
+
+func TestCachingCommandRunner_DoesNotCacheWriteTools(t *testing.T) {
+	countingRunner := &CountingRunner{}
+
+	runner := goops.NewCachingCommandRunner(countingRunner)
+
+	for range 2 {
+		_, err := runner.Run("status-set", "active")
+		if err != nil {
+			t.Fatalf("Run returned an error: %v", err)
+		}
+	}
+
+	if countingRunner.Calls["status-set"] != 2 {
+		t.Errorf("Expected status-set to run twice, ran %d times", countingRunner.Calls["status-set"])
+	}
+}
+
+func TestCachingCommandRunner_InvalidatesOnWrite(t *testing.T) {
+	countingRunner := &CountingRunner{Output: []byte(`{}`)}
+
+	runner := goops.NewCachingCommandRunner(countingRunner)
+
+	calls := [][]string{
+		{"relation-get", "-r=certificates:0", "-", "example/0", "--format=json"},
+		{"config-get", "--all", "--format=json"},
+		{"relation-set", "-r=certificates:0", "key=value"},
+		{"relation-get", "-r=certificates:0", "-", "example/0", "--format=json"},
+		{"config-get", "--all", "--format=json"},
+	}
+
+	for _, call := range calls {
+		_, err := runner.Run(call[0], call[1:]...)
+		if err != nil {
+			t.Fatalf("Run returned an error: %v", err)
+		}
+	}
+
+	if countingRunner.Calls["relation-get"] != 2 {
+		t.Errorf("Expected relation-get to run again after relation-set, ran %d times", countingRunner.Calls["relation-get"])
+	}
+
+	if countingRunner.Calls["config-get"] != 1 {
+		t.Errorf("Expected config-get to stay cached, ran %d times", countingRunner.Calls["config-get"])
+	}
+}
@@ -0,0 +1,113 @@
+package goops
+
+// Client runs Juju hook tools, reads the hook environment and connects to Pebble.
+// The package-level functions (GetConfig, SetUnitStatus, ...) use a default Client
+// backed by the real hook tools. Charms and tests that need isolation, for example
+// to run several charms in one process or tests in parallel, can create their own
+// Client with NewClient and call its methods instead.
+type Client struct {
+	runner       CommandRunner
+	envGetter    EnvironmentGetter
+	pebbleGetter PebbleGetter
+	statuses     *statusCollector
+	sensitive    *sensitiveValues
+	schemas      *interfaceSchemaRegistry
+
+	cacheHookTools        bool
+	onInvalidRelationData func(*RelationDataValidationError)
+	onLogRecord           func(LogRecord)
+}
+
+var defaultClient = NewClient()
+
+// NewClient creates a Client. Unless overridden by options, the client runs the
+// real hook tools, reads the process environment and connects to the Pebble
+// socket of each container. Hook tools are run by calling the unit agent over
+// its jujuc socket when the hook environment exposes one, and by executing the
+// hook tool binaries otherwise.
+func NewClient(opts ...func(*Client)) *Client {
+	c := &Client{
+		envGetter:    &realExecutionEnvironment{},
+		pebbleGetter: &realPebbleGetter{},
+		statuses:     &statusCollector{},
+		sensitive:    &sensitiveValues{},
+		schemas:      &interfaceSchemaRegistry{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.runner == nil {
+		c.runner = newDefaultCommandRunner(c.envGetter)
+	}
+
+	if c.cacheHookTools {
+		c.runner = NewCachingCommandRunner(c.runner)
+	}
+
+	return c
+}
+
+// WithCommandRunner sets the runner used to execute hook tools.
+func WithCommandRunner(runner CommandRunner) func(*Client) {
+	return func(c *Client) {
+		c.runner = runner
+	}
+}
+
+// WithEnvGetter sets the getter used to read environment variables and charm files.
+func WithEnvGetter(envGetter EnvironmentGetter) func(*Client) {
+	return func(c *Client) {
+		c.envGetter = envGetter
+	}
+}
+
+// WithPebbleGetter sets the getter used to create Pebble clients.
+func WithPebbleGetter(getter PebbleGetter) func(*Client) {
+	return func(c *Client) {
+		c.pebbleGetter = getter
+	}
+}
+
+// WithHookToolCache makes the client cache the results of read-only hook tools
+// for its lifetime, as described in NewCachingCommandRunner.
+func WithHookToolCache() func(*Client) {
+	return func(c *Client) {
+		c.cacheHookTools = true
+	}
+}
+
+// WithInvalidRelationDataHandler sets a function called whenever relation data
+// fails the schema registered for its interface, in addition to the error being returned.
+func WithInvalidRelationDataHandler(handler func(*RelationDataValidationError)) func(*Client) {
+	return func(c *Client) {
+		c.onInvalidRelationData = handler
+	}
+}
+
+// WithLogRecordHandler sets a function called with every record written to juju-log
+// by a SlogHandler, in addition to the record being logged.
+func WithLogRecordHandler(handler func(LogRecord)) func(*Client) {
+	return func(c *Client) {
+		c.onLogRecord = handler
+	}
+}
+
+// WithInterfaceSchemasFrom registers on the new client the interface schemas registered
+// on other, for example those registered by integration libraries on the default client.
+func WithInterfaceSchemasFrom(other *Client) func(*Client) {
+	return func(c *Client) {
+		other.schemas.copyTo(c.schemas)
+	}
+}
+
+// DefaultClient returns the client used by the package-level functions.
+func DefaultClient() *Client {
+	return defaultClient
+}
+
+// SetDefaultClient replaces the client used by the package-level functions.
+func SetDefaultClient(c *Client) {
+	defaultClient = c
+}
@@ -0,0 +1,65 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestClientUsesOwnRunner(t *testing.T) {
+	defaultRunner := &FakeRunner{
+		Output: []byte(`false`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(defaultRunner)
+
+	clientRunner := &FakeRunner{
+		Output: []byte(`true`),
+		Err:    nil,
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(clientRunner))
+
+	result, err := client.IsLeader()
+	if err != nil {
+		t.Fatalf("IsLeader returned an error: %v", err)
+	}
+
+	if result != true {
+		t.Errorf("Expected true, got %v", result)
+	}
+
+	if clientRunner.Command != "is-leader" {
+		t.Errorf("Expected command %q, got %q", "is-leader", clientRunner.Command)
+	}
+
+	if defaultRunner.Command != "" {
+		t.Errorf("Expected default runner not to be called, got command %q", defaultRunner.Command)
+	}
+}
+
+func TestSetDefaultClient(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`true`),
+		Err:    nil,
+	}
+
+	previous := goops.DefaultClient()
+	defer goops.SetDefaultClient(previous)
+
+	goops.SetDefaultClient(goops.NewClient(goops.WithCommandRunner(fakeRunner)))
+
+	result, err := goops.IsLeader()
+	if err != nil {
+		t.Fatalf("IsLeader returned an error: %v", err)
+	}
+
+	if result != true {
+		t.Errorf("Expected true, got %v", result)
+	}
+
+	if goops.GetCommandRunner() != fakeRunner {
+		t.Errorf("Expected GetCommandRunner to return the default client's runner")
+	}
+}
@@ -1,42 +1,19 @@
 package main
 
 import (
-	"os"
-
 	"github.com/gruyaume/goops"
 	"github.com/gruyaume/goops/internal/charm"
 )
 
 // Example charm using `goops`
 func main() {
-	env := goops.ReadEnv()
-
-	if env.ActionName != "" {
-		goops.LogInfof("Action name: %s", env.ActionName)
-
-		switch env.ActionName {
-		case "get-ca-certificate":
-			err := charm.HandleGetCACertificateAction()
-			if err != nil {
-				goops.LogErrorf("Error handling get-ca-certificate action: %s", err.Error())
-				os.Exit(1)
-			}
+	dispatcher := goops.NewDispatcher()
 
-			goops.LogInfof("Handled get-ca-certificate action successfully")
-			os.Exit(0)
-		default:
-			goops.LogErrorf("Action '%s' not recognized, exiting", env.ActionName)
-			os.Exit(1)
-		}
-	}
+	goops.RegisterAction(dispatcher, "get-ca-certificate", charm.HandleGetCACertificateAction)
 
-	if env.HookName != "" {
-		goops.LogInfof("Hook name: %s", env.HookName)
+	dispatcher.Reconcile(func(goops.Event) error {
+		return charm.Configure()
+	})
 
-		err := charm.Configure()
-		if err != nil {
-			goops.LogErrorf("Error handling default hook: %s", err.Error())
-			os.Exit(1)
-		}
-	}
+	dispatcher.Main()
 }
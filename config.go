@@ -3,15 +3,52 @@ package goops
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 )
 
 const (
 	configGetCommand = "config-get"
 )
 
+// SecretConfig holds a config option of type secret.
+// When a config struct field has this type, GetConfig resolves the secret URI set
+// in the option to the content of the secret.
+type SecretConfig struct {
+	ID      string
+	Content map[string]string
+}
+
+func (s *SecretConfig) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.ID)
+}
+
+func (s SecretConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ID)
+}
+
+// SecretConfigError is returned by GetConfig when the secret referenced by a
+// config option cannot be read, typically because the user did not grant it
+// to the application.
+type SecretConfigError struct {
+	Option   string
+	SecretID string
+	Err      error
+}
+
+func (e *SecretConfigError) Error() string {
+	return fmt.Sprintf("failed to read secret %s set in config option %q, make sure it was granted to the application with juju grant-secret: %v", e.SecretID, e.Option, e.Err)
+}
+
+func (e *SecretConfigError) Unwrap() error {
+	return e.Err
+}
+
+var secretConfigType = reflect.TypeFor[SecretConfig]()
+
 // GetConfig retrieves the Juju configuration options and unmarshals them into the provided config struct.
-func GetConfig(config any) error {
-	commandRunner := GetCommandRunner()
+// Fields of type SecretConfig are resolved to the content of the secret they reference.
+func (c *Client) GetConfig(config any) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--all", "--format=json"}
 
@@ -25,5 +62,53 @@ func GetConfig(config any) error {
 		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	return c.resolveSecretConfig(config)
+}
+
+// GetConfig retrieves the Juju configuration options and unmarshals them into the provided config struct.
+// Fields of type SecretConfig are resolved to the content of the secret they reference.
+func GetConfig(config any) error {
+	return defaultClient.GetConfig(config)
+}
+
+// resolveSecretConfig fetches the content of the secrets referenced by SecretConfig fields of the config struct.
+func (c *Client) resolveSecretConfig(config any) error {
+	value := reflect.Indirect(reflect.ValueOf(config))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, field := range dataBagFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Type() != secretConfigType {
+			continue
+		}
+
+		secret, _ := fieldValue.Addr().Interface().(*SecretConfig)
+		if secret.ID == "" {
+			continue
+		}
+
+		err := c.requireFeature(FeatureSecretConfig)
+		if err != nil {
+			return &SecretConfigError{Option: field.key, SecretID: secret.ID, Err: err}
+		}
+
+		content, err := c.GetSecretByID(secret.ID, false, true)
+		if err != nil {
+			return &SecretConfigError{Option: field.key, SecretID: secret.ID, Err: err}
+		}
+
+		secret.Content = content
+	}
+
 	return nil
 }
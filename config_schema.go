@@ -0,0 +1,229 @@
+package goops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigOptionType string
+
+const (
+	ConfigString  ConfigOptionType = "string"
+	ConfigInt     ConfigOptionType = "int"
+	ConfigFloat   ConfigOptionType = "float"
+	ConfigBoolean ConfigOptionType = "boolean"
+	ConfigSecret  ConfigOptionType = "secret"
+)
+
+// ConfigOption describes a configuration option declared by the charm.
+// Default is nil when the option has no default value.
+type ConfigOption struct {
+	Type        ConfigOptionType `yaml:"type"`
+	Default     any              `yaml:"default,omitempty"`
+	Description string           `yaml:"description,omitempty"`
+}
+
+// ConfigSchema is the set of configuration options declared in the charm's config.yaml,
+// or in the config section of its charmcraft.yaml.
+type ConfigSchema struct {
+	Options map[string]ConfigOption `yaml:"options"`
+}
+
+// ConfigValidationError lists the config struct fields that do not match the config schema.
+type ConfigValidationError struct {
+	InvalidKeys []InvalidKey
+}
+
+func (e *ConfigValidationError) Error() string {
+	keys := make([]string, 0, len(e.InvalidKeys))
+	for _, invalidKey := range e.InvalidKeys {
+		keys = append(keys, fmt.Sprintf("%s: %s", invalidKey.Key, invalidKey.Reason))
+	}
+
+	return "config does not match schema: " + strings.Join(keys, "; ")
+}
+
+// ParseConfigSchema parses the content of a config.yaml file.
+// The content of a charmcraft.yaml file is also accepted, in which case the options
+// are read from its config section.
+func ParseConfigSchema(data []byte) (*ConfigSchema, error) {
+	var file struct {
+		Options map[string]ConfigOption `yaml:"options"`
+		Config  *ConfigSchema           `yaml:"config"`
+	}
+
+	err := yaml.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config schema: %w", err)
+	}
+
+	schema := &ConfigSchema{Options: file.Options}
+	if file.Config != nil {
+		schema = file.Config
+	}
+
+	if schema.Options == nil {
+		schema.Options = make(map[string]ConfigOption)
+	}
+
+	for name, option := range schema.Options {
+		if !option.Type.valid() {
+			return nil, fmt.Errorf("config option %q has unsupported type %q", name, option.Type)
+		}
+
+		if option.Default != nil && !option.Type.accepts(option.Default) {
+			return nil, fmt.Errorf("config option %q has a default of type %T, expected %s", name, option.Default, option.Type)
+		}
+	}
+
+	return schema, nil
+}
+
+// ReadConfigSchema reads the config options of the charm from config.yaml in the charm
+// directory, falling back to the config section of charmcraft.yaml.
+func (c *Client) ReadConfigSchema() (*ConfigSchema, error) {
+	data, err := c.readCharmFile("config.yaml", "charmcraft.yaml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return &ConfigSchema{Options: make(map[string]ConfigOption)}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config schema: %w", err)
+	}
+
+	return ParseConfigSchema(data)
+}
+
+// ReadConfigSchema reads the config options of the charm from config.yaml in the charm
+// directory, falling back to the config section of charmcraft.yaml.
+func ReadConfigSchema() (*ConfigSchema, error) {
+	return defaultClient.ReadConfigSchema()
+}
+
+// Defaults returns the default value of every option that has one.
+func (s *ConfigSchema) Defaults() map[string]any {
+	defaults := make(map[string]any)
+
+	for name, option := range s.Options {
+		if option.Default != nil {
+			defaults[name] = option.Default
+		}
+	}
+
+	return defaults
+}
+
+// Validate checks that the struct config, as passed to GetConfig, matches the schema.
+// Each option must map to a field through its json tag, each field must map to an
+// option, and field types must be able to hold the option's values. Fields whose
+// type implements json.Unmarshaler are not type checked.
+// A *ConfigValidationError listing every mismatch is returned.
+func (s *ConfigSchema) Validate(config any) error {
+	value, err := structValue(config)
+	if err != nil {
+		return err
+	}
+
+	var invalidKeys []InvalidKey
+
+	fields := make(map[string]reflect.Type)
+
+	for _, field := range dataBagFields(value.Type()) {
+		fieldType := value.Type().Field(field.index).Type
+		fields[field.key] = fieldType
+
+		option, ok := s.Options[field.key]
+		if !ok {
+			invalidKeys = append(invalidKeys, InvalidKey{Key: field.key, Reason: "no such config option"})
+			continue
+		}
+
+		if !option.Type.fitsField(fieldType) {
+			invalidKeys = append(invalidKeys, InvalidKey{
+				Key:    field.key,
+				Reason: fmt.Sprintf("field of type %s cannot hold %s values", fieldType, option.Type),
+			})
+		}
+	}
+
+	for name := range s.Options {
+		if _, ok := fields[name]; !ok {
+			invalidKeys = append(invalidKeys, InvalidKey{Key: name, Reason: "option has no field"})
+		}
+	}
+
+	if len(invalidKeys) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(invalidKeys, func(i, j int) bool {
+		return invalidKeys[i].Key < invalidKeys[j].Key
+	})
+
+	return &ConfigValidationError{InvalidKeys: invalidKeys}
+}
+
+func (t ConfigOptionType) valid() bool {
+	switch t {
+	case ConfigString, ConfigInt, ConfigFloat, ConfigBoolean, ConfigSecret:
+		return true
+	default:
+		return false
+	}
+}
+
+// accepts reports whether a value decoded from YAML is valid for the option type.
+func (t ConfigOptionType) accepts(value any) bool {
+	switch value.(type) {
+	case string:
+		return t == ConfigString || t == ConfigSecret
+	case int, int64, uint64:
+		return t == ConfigInt || t == ConfigFloat
+	case float64:
+		return t == ConfigFloat
+	case bool:
+		return t == ConfigBoolean
+	default:
+		return false
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// fitsField reports whether a struct field of type fieldType can hold the option's values.
+func (t ConfigOptionType) fitsField(fieldType reflect.Type) bool {
+	if fieldType == secretConfigType || fieldType == reflect.PointerTo(secretConfigType) {
+		return t == ConfigSecret
+	}
+
+	if reflect.PointerTo(fieldType).Implements(jsonUnmarshalerType) {
+		return true
+	}
+
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.String:
+		return t == ConfigString || t == ConfigSecret
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return t == ConfigInt
+	case reflect.Float32, reflect.Float64:
+		return t == ConfigFloat || t == ConfigInt
+	case reflect.Bool:
+		return t == ConfigBoolean
+	default:
+		return false
+	}
+}
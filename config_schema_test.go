@@ -0,0 +1,154 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+const exampleCharmcraftConfig = `
+name: example
+config:
+  options:
+    ca-common-name:
+      type: string
+      default: self-signed-certificates
+      description: Common name of the CA.
+    validity-days:
+      type: int
+      default: 365
+    debug:
+      type: boolean
+`
+
+func TestParseConfigSchema_Charmcraft(t *testing.T) {
+	schema, err := goops.ParseConfigSchema([]byte(exampleCharmcraftConfig))
+	if err != nil {
+		t.Fatalf("ParseConfigSchema returned an error: %v", err)
+	}
+
+	if len(schema.Options) != 3 {
+		t.Fatalf("Expected 3 options, got %d", len(schema.Options))
+	}
+
+	option := schema.Options["ca-common-name"]
+	if option.Type != goops.ConfigString {
+		t.Errorf("Expected type %q, got %q", goops.ConfigString, option.Type)
+	}
+
+	if option.Description != "Common name of the CA." {
+		t.Errorf("Unexpected description: %q", option.Description)
+	}
+
+	defaults := schema.Defaults()
+	if len(defaults) != 2 || defaults["ca-common-name"] != "self-signed-certificates" || defaults["validity-days"] != 365 {
+		t.Errorf("Unexpected defaults: %v", defaults)
+	}
+}
+
+func TestParseConfigSchema_InvalidDefault(t *testing.T) {
+	_, err := goops.ParseConfigSchema([]byte(`
+options:
+  port:
+    type: int
+    default: not-a-number
+`))
+	if err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+}
+
+func TestReadConfigSchema_FallsBackToCharmcraft(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{"JUJU_CHARM_DIR": "/charm"},
+		Files: map[string][]byte{
+			"/charm/charmcraft.yaml": []byte(exampleCharmcraftConfig),
+		},
+	}))
+
+	schema, err := client.ReadConfigSchema()
+	if err != nil {
+		t.Fatalf("ReadConfigSchema returned an error: %v", err)
+	}
+
+	if _, ok := schema.Options["validity-days"]; !ok {
+		t.Errorf("Expected validity-days option, got %v", schema.Options)
+	}
+}
+
+func TestConfigSchemaValidate(t *testing.T) {
+	schema, err := goops.ParseConfigSchema([]byte(exampleCharmcraftConfig))
+	if err != nil {
+		t.Fatalf("ParseConfigSchema returned an error: %v", err)
+	}
+
+	type validConfig struct {
+		CACommonName string `json:"ca-common-name"`
+		ValidityDays int    `json:"validity-days"`
+		Debug        *bool  `json:"debug"`
+	}
+
+	err = schema.Validate(&validConfig{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	type invalidConfig struct {
+		CACommonName string `json:"ca-common-name"`
+		ValidityDays string `json:"validity-days"`
+		Unknown      string `json:"unknown"`
+	}
+
+	err = schema.Validate(&invalidConfig{})
+
+	var validationErr *goops.ConfigValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a ConfigValidationError, got %v", err)
+	}
+
+	expected := []goops.InvalidKey{
+		{Key: "debug", Reason: "option has no field"},
+		{Key: "unknown", Reason: "no such config option"},
+		{Key: "validity-days", Reason: "field of type string cannot hold int values"},
+	}
+
+	if len(validationErr.InvalidKeys) != len(expected) {
+		t.Fatalf("Expected invalid keys %v, got %v", expected, validationErr.InvalidKeys)
+	}
+
+	for i, invalidKey := range expected {
+		if validationErr.InvalidKeys[i] != invalidKey {
+			t.Errorf("Expected invalid key %v, got %v", invalidKey, validationErr.InvalidKeys[i])
+		}
+	}
+}
+
+func TestConfigSchemaValidate_SecretConfig(t *testing.T) {
+	schema, err := goops.ParseConfigSchema([]byte(`
+options:
+  database-password:
+    type: secret
+  username:
+    type: string
+`))
+	if err != nil {
+		t.Fatalf("ParseConfigSchema returned an error: %v", err)
+	}
+
+	type config struct {
+		DatabasePassword goops.SecretConfig  `json:"database-password"`
+		Username         *goops.SecretConfig `json:"username"`
+	}
+
+	err = schema.Validate(&config{})
+
+	var validationErr *goops.ConfigValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a ConfigValidationError, got %v", err)
+	}
+
+	if len(validationErr.InvalidKeys) != 1 || validationErr.InvalidKeys[0].Key != "username" {
+		t.Errorf("Expected only username to be invalid, got %v", validationErr.InvalidKeys)
+	}
+}
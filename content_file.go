@@ -0,0 +1,79 @@
+package goops
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxInlineContentSize is the size in bytes of the key=value arguments above which
+// relation data is passed to relation-set in a file rather than on the command line,
+// which is limited in size and readable in process listings.
+const maxInlineContentSize = 32 * 1024
+
+// base64KeySuffix marks secret content keys whose value is base64 encoded binary data.
+const base64KeySuffix = "#base64"
+
+// inlineContentSize returns the size of content passed as key=value arguments.
+func inlineContentSize(content map[string]string) int {
+	size := 0
+	for key, value := range content {
+		size += len(key) + len(value) + 1
+	}
+
+	return size
+}
+
+// secretFileContent returns the content of a secret as written to its content file.
+// Binary values are base64 encoded under their key suffixed with #base64.
+func secretFileContent(content map[string]string, binaryContent map[string][]byte) map[string]string {
+	fileContent := make(map[string]string, len(content)+len(binaryContent))
+	for key, value := range content {
+		fileContent[key] = value
+	}
+
+	for key, value := range binaryContent {
+		fileContent[key+base64KeySuffix] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	return fileContent
+}
+
+// writeContentFile writes content as YAML to a temporary file readable only by
+// the charm, so that it can be passed to a hook tool with --file instead of on
+// the command line. The returned function removes the file.
+func writeContentFile(content map[string]string) (string, func(), error) {
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal content: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "goops-content-*.yaml")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create content file: %w", err)
+	}
+
+	remove := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		_ = file.Close()
+
+		remove()
+
+		return "", nil, fmt.Errorf("failed to write content file: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		remove()
+
+		return "", nil, fmt.Errorf("failed to close content file: %w", err)
+	}
+
+	return file.Name(), remove, nil
+}
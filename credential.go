@@ -1,14 +1,41 @@
 package goops
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 const (
 	credentialGetCommand = "credential-get" // #nosec G101
 )
 
+// CloudCredential holds the credential of a cloud, as returned by credential-get.
+// The keys of Attributes depend on the cloud type and the auth type.
+type CloudCredential struct {
+	AuthType   string            `json:"auth-type"`
+	Attributes map[string]string `json:"attrs,omitempty"`
+	Redacted   []string          `json:"redacted,omitempty"`
+}
+
+// CloudSpec describes the cloud the model runs on, as returned by credential-get.
+// It is only available to charms that are trusted, with `juju trust`.
+type CloudSpec struct {
+	Type              string           `json:"type"`
+	Name              string           `json:"name"`
+	Region            string           `json:"region,omitempty"`
+	Endpoint          string           `json:"endpoint,omitempty"`
+	IdentityEndpoint  string           `json:"identity-endpoint,omitempty"`
+	StorageEndpoint   string           `json:"storage-endpoint,omitempty"`
+	Credential        *CloudCredential `json:"credential,omitempty"`
+	CACertificates    []string         `json:"cacertificates,omitempty"`
+	SkipTLSVerify     bool             `json:"skip-tls-verify,omitempty"`
+	IsControllerCloud bool             `json:"is-controller-cloud,omitempty"`
+}
+
 // GetCredential retrieves cloud credentials.
-func GetCredential() (map[string]string, error) {
-	commandRunner := GetCommandRunner()
+// credential-get returns nested data that this map cannot hold for most clouds, use GetCloudSpec instead.
+func (c *Client) GetCredential() (map[string]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--format=json"}
 
@@ -26,3 +53,163 @@ func GetCredential() (map[string]string, error) {
 
 	return credential, nil
 }
+
+// GetCredential retrieves cloud credentials.
+// credential-get returns nested data that this map cannot hold for most clouds, use GetCloudSpec instead.
+func GetCredential() (map[string]string, error) {
+	return defaultClient.GetCredential()
+}
+
+// GetCloudSpec retrieves the cloud the model runs on, with its credential.
+// The charm must be trusted. The credential attributes are marked sensitive.
+func (c *Client) GetCloudSpec() (*CloudSpec, error) {
+	commandRunner := c.commandRunner()
+
+	args := []string{"--format=json"}
+
+	output, err := commandRunner.Run(credentialGetCommand, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloud spec: %w", err)
+	}
+
+	var spec CloudSpec
+
+	err = json.Unmarshal(output, &spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cloud spec: %w", err)
+	}
+
+	if spec.Credential != nil {
+		for _, value := range spec.Credential.Attributes {
+			c.sensitive.add(value)
+		}
+	}
+
+	return &spec, nil
+}
+
+// GetCloudSpec retrieves the cloud the model runs on, with its credential.
+// The charm must be trusted. The credential attributes are marked sensitive.
+func GetCloudSpec() (*CloudSpec, error) {
+	return defaultClient.GetCloudSpec()
+}
+
+// KubernetesConfig holds what a Kubernetes client needs to reach the cluster of a
+// kubernetes cloud. Depending on the auth type, either Token, Username and
+// Password, or ClientCertificateData and ClientKeyData are set.
+type KubernetesConfig struct {
+	Host                  string
+	CAData                []byte
+	Insecure              bool
+	Token                 string
+	Username              string
+	Password              string
+	ClientCertificateData []byte
+	ClientKeyData         []byte
+}
+
+// KubernetesConfig returns the configuration of a Kubernetes client for a kubernetes cloud.
+func (s *CloudSpec) KubernetesConfig() (*KubernetesConfig, error) {
+	attributes, err := s.attributes("kubernetes")
+	if err != nil {
+		return nil, err
+	}
+
+	config := &KubernetesConfig{
+		Host:     s.Endpoint,
+		Insecure: s.SkipTLSVerify,
+		Token:    attributes["Token"],
+		Username: attributes["username"],
+		Password: attributes["password"],
+	}
+
+	if len(s.CACertificates) > 0 {
+		config.CAData = []byte(s.CACertificates[0])
+	}
+
+	if attributes["ClientCertificateData"] != "" {
+		config.ClientCertificateData = []byte(attributes["ClientCertificateData"])
+		config.ClientKeyData = []byte(attributes["ClientKeyData"])
+	}
+
+	return config, nil
+}
+
+// OpenStackConfig holds what an OpenStack client needs to authenticate against
+// the identity service of an openstack cloud.
+type OpenStackConfig struct {
+	AuthURL           string
+	Region            string
+	Username          string
+	Password          string
+	TenantName        string
+	TenantID          string
+	DomainName        string
+	ProjectDomainName string
+	UserDomainName    string
+	AccessKey         string
+	SecretKey         string
+	CACertificates    []string
+	Insecure          bool
+}
+
+// OpenStackConfig returns the configuration of an OpenStack client for an openstack cloud.
+func (s *CloudSpec) OpenStackConfig() (*OpenStackConfig, error) {
+	attributes, err := s.attributes("openstack")
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenStackConfig{
+		AuthURL:           s.Endpoint,
+		Region:            s.Region,
+		Username:          attributes["username"],
+		Password:          attributes["password"],
+		TenantName:        attributes["tenant-name"],
+		TenantID:          attributes["tenant-id"],
+		DomainName:        attributes["domain-name"],
+		ProjectDomainName: attributes["project-domain-name"],
+		UserDomainName:    attributes["user-domain-name"],
+		AccessKey:         attributes["access-key"],
+		SecretKey:         attributes["secret-key"],
+		CACertificates:    s.CACertificates,
+		Insecure:          s.SkipTLSVerify,
+	}, nil
+}
+
+// AWSConfig holds what an AWS client needs to authenticate in the region of an ec2 cloud.
+// The keys are empty when the cloud uses an instance role.
+type AWSConfig struct {
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// AWSConfig returns the configuration of an AWS client for an ec2 cloud.
+func (s *CloudSpec) AWSConfig() (*AWSConfig, error) {
+	attributes, err := s.attributes("ec2")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSConfig{
+		Region:          s.Region,
+		Endpoint:        s.Endpoint,
+		AccessKeyID:     attributes["access-key"],
+		SecretAccessKey: attributes["secret-key"],
+	}, nil
+}
+
+// attributes returns the credential attributes, checking that the cloud has the expected type.
+func (s *CloudSpec) attributes(cloudType string) (map[string]string, error) {
+	if s.Type != cloudType {
+		return nil, fmt.Errorf("cloud %s is of type %q, not %q", s.Name, s.Type, cloudType)
+	}
+
+	if s.Credential == nil {
+		return map[string]string{}, nil
+	}
+
+	return s.Credential.Attributes, nil
+}
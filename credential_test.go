@@ -36,3 +36,102 @@ func TestCredentialGet_Success(t *testing.T) {
 		t.Errorf("Expected argument %q, got %q", "--format=json", fakeRunner.Args[0])
 	}
 }
+
+func TestGetCloudSpec_Kubernetes(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{
+			"type": "kubernetes",
+			"name": "microk8s",
+			"region": "localhost",
+			"endpoint": "https://10.0.0.1:16443",
+			"credential": {
+				"auth-type": "clientcertificate",
+				"attrs": {"ClientCertificateData": "client-cert", "ClientKeyData": "client-key", "Token": "token"}
+			},
+			"cacertificates": ["ca-cert"],
+			"is-controller-cloud": true
+		}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	spec, err := client.GetCloudSpec()
+	if err != nil {
+		t.Fatalf("GetCloudSpec returned an error: %v", err)
+	}
+
+	if spec.Type != "kubernetes" || spec.Region != "localhost" || !spec.IsControllerCloud {
+		t.Errorf("Unexpected cloud spec %+v", spec)
+	}
+
+	config, err := spec.KubernetesConfig()
+	if err != nil {
+		t.Fatalf("KubernetesConfig returned an error: %v", err)
+	}
+
+	if config.Host != "https://10.0.0.1:16443" {
+		t.Errorf("Expected host %q, got %q", "https://10.0.0.1:16443", config.Host)
+	}
+
+	if string(config.CAData) != "ca-cert" || string(config.ClientCertificateData) != "client-cert" || string(config.ClientKeyData) != "client-key" || config.Token != "token" {
+		t.Errorf("Unexpected kubernetes config %+v", config)
+	}
+
+	_, err = spec.AWSConfig()
+	if err == nil {
+		t.Error("Expected AWSConfig to fail for a kubernetes cloud")
+	}
+}
+
+func TestGetCloudSpec_OpenStack(t *testing.T) {
+	spec := &goops.CloudSpec{
+		Type:     "openstack",
+		Name:     "serverstack",
+		Region:   "RegionOne",
+		Endpoint: "https://keystone.example.com:5000/v3",
+		Credential: &goops.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username":            "admin",
+				"password":            "secret",
+				"tenant-name":         "admin",
+				"project-domain-name": "default",
+				"user-domain-name":    "default",
+			},
+		},
+	}
+
+	config, err := spec.OpenStackConfig()
+	if err != nil {
+		t.Fatalf("OpenStackConfig returned an error: %v", err)
+	}
+
+	if config.AuthURL != spec.Endpoint || config.Region != "RegionOne" || config.Username != "admin" || config.Password != "secret" {
+		t.Errorf("Unexpected openstack config %+v", config)
+	}
+
+	if config.TenantName != "admin" || config.ProjectDomainName != "default" || config.UserDomainName != "default" {
+		t.Errorf("Unexpected openstack config %+v", config)
+	}
+}
+
+func TestGetCloudSpec_AWS(t *testing.T) {
+	spec := &goops.CloudSpec{
+		Type:   "ec2",
+		Name:   "aws",
+		Region: "us-east-1",
+		Credential: &goops.CloudCredential{
+			AuthType:   "access-key",
+			Attributes: map[string]string{"access-key": "AKIA", "secret-key": "secret"},
+		},
+	}
+
+	config, err := spec.AWSConfig()
+	if err != nil {
+		t.Fatalf("AWSConfig returned an error: %v", err)
+	}
+
+	if config.Region != "us-east-1" || config.AccessKeyID != "AKIA" || config.SecretAccessKey != "secret" {
+		t.Errorf("Unexpected aws config %+v", config)
+	}
+}
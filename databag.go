@@ -0,0 +1,197 @@
+package goops
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DataBagFieldError reports a databag key whose value could not be decoded into its struct field.
+type DataBagFieldError struct {
+	Key   string
+	Field string
+	Err   error
+}
+
+func (e *DataBagFieldError) Error() string {
+	return fmt.Sprintf("key %q (field %s): %v", e.Key, e.Field, e.Err)
+}
+
+func (e *DataBagFieldError) Unwrap() error {
+	return e.Err
+}
+
+// DataBagDecodeError lists every databag key that could not be decoded.
+type DataBagDecodeError struct {
+	Fields []*DataBagFieldError
+}
+
+func (e *DataBagDecodeError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Error())
+	}
+
+	return "failed to decode databag: " + strings.Join(messages, "; ")
+}
+
+type dataBagField struct {
+	key       string
+	index     int
+	omitEmpty bool
+}
+
+// dataBagFields returns the databag keys of a struct type.
+// Keys are taken from the json tag of each exported field, falling back to the field name.
+func dataBagFields(t reflect.Type) []dataBagField {
+	var fields []dataBagField
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = structField.Name
+		}
+
+		fields = append(fields, dataBagField{
+			key:       name,
+			index:     i,
+			omitEmpty: strings.Contains(","+options+",", ",omitempty,"),
+		})
+	}
+
+	return fields
+}
+
+func structValue(v any) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("expected a struct, got nil %T", v)
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	return value, nil
+}
+
+// MarshalDataBag converts a struct into relation databag content.
+// Each exported field becomes a key named after its json tag. String fields and
+// string pointers are stored as-is and every other field is JSON-encoded, following
+// the charm-relation-interfaces convention. Fields tagged with omitempty are set to
+// an empty value when they hold their zero value, which deletes the key when the
+// content is written with relation-set.
+func MarshalDataBag(v any) (map[string]string, error) {
+	value, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string)
+
+	for _, field := range dataBagFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+
+		if field.omitEmpty && fieldValue.IsZero() {
+			data[field.key] = ""
+			continue
+		}
+
+		if isStringPointer(fieldValue.Type()) {
+			data[field.key] = ""
+
+			if !fieldValue.IsNil() {
+				data[field.key] = fieldValue.Elem().String()
+			}
+
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.String {
+			data[field.key] = fieldValue.String()
+			continue
+		}
+
+		encoded, err := json.Marshal(fieldValue.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key %q: %w", field.key, err)
+		}
+
+		data[field.key] = string(encoded)
+	}
+
+	return data, nil
+}
+
+// UnmarshalDataBag decodes relation databag content into the struct pointed to by v.
+// It is the inverse of MarshalDataBag. Keys missing from the databag, or holding an
+// empty value, leave their field untouched. When some values cannot be decoded, the other fields are still
+// populated and a *DataBagDecodeError listing the failing keys is returned.
+func UnmarshalDataBag(data map[string]string, v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", v)
+	}
+
+	value, err := structValue(v)
+	if err != nil {
+		return err
+	}
+
+	var decodeErr DataBagDecodeError
+
+	for _, field := range dataBagFields(value.Type()) {
+		raw := data[field.key]
+		if raw == "" {
+			continue
+		}
+
+		fieldValue := value.Field(field.index)
+
+		if isStringPointer(fieldValue.Type()) {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			fieldValue.Elem().SetString(raw)
+
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.String {
+			fieldValue.SetString(raw)
+			continue
+		}
+
+		err := json.Unmarshal([]byte(raw), fieldValue.Addr().Interface())
+		if err != nil {
+			decodeErr.Fields = append(decodeErr.Fields, &DataBagFieldError{
+				Key:   field.key,
+				Field: value.Type().Field(field.index).Name,
+				Err:   err,
+			})
+		}
+	}
+
+	if len(decodeErr.Fields) > 0 {
+		return &decodeErr
+	}
+
+	return nil
+}
+
+func isStringPointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.String
+}
@@ -0,0 +1,237 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+type exampleDataBag struct {
+	Hostname string   `json:"hostname"`
+	Port     int      `json:"port"`
+	Enabled  bool     `json:"enabled"`
+	Tags     []string `json:"tags,omitempty"`
+	Ignored  string   `json:"-"`
+}
+
+func TestMarshalDataBag(t *testing.T) {
+	data, err := goops.MarshalDataBag(exampleDataBag{
+		Hostname: "example.com",
+		Port:     443,
+		Enabled:  true,
+		Ignored:  "ignored",
+	})
+	if err != nil {
+		t.Fatalf("MarshalDataBag returned an error: %v", err)
+	}
+
+	expected := map[string]string{
+		"hostname": "example.com",
+		"port":     "443",
+		"enabled":  "true",
+		"tags":     "",
+	}
+
+	if len(data) != len(expected) {
+		t.Fatalf("Expected %d keys, got %d: %v", len(expected), len(data), data)
+	}
+
+	for key, value := range expected {
+		if data[key] != value {
+			t.Errorf("Expected %q=%q, got %q", key, value, data[key])
+		}
+	}
+}
+
+type optionalDataBag struct {
+	Hostname *string `json:"hostname"`
+	Path     string  `json:"path,omitempty"`
+	Port     int     `json:"port,omitempty"`
+}
+
+func TestMarshalDataBag_StringPointer(t *testing.T) {
+	hostname := "example.com"
+
+	data, err := goops.MarshalDataBag(optionalDataBag{Hostname: &hostname, Path: "/api"})
+	if err != nil {
+		t.Fatalf("MarshalDataBag returned an error: %v", err)
+	}
+
+	if data["hostname"] != "example.com" {
+		t.Errorf("Expected hostname to be stored as %q, got %q", "example.com", data["hostname"])
+	}
+
+	data, err = goops.MarshalDataBag(optionalDataBag{})
+	if err != nil {
+		t.Fatalf("MarshalDataBag returned an error: %v", err)
+	}
+
+	value, ok := data["hostname"]
+	if !ok || value != "" {
+		t.Errorf("Expected a nil string pointer to be stored as an empty value, got %q (present: %v)", value, ok)
+	}
+
+	var bag optionalDataBag
+
+	err = goops.UnmarshalDataBag(map[string]string{"hostname": "example.com"}, &bag)
+	if err != nil {
+		t.Fatalf("UnmarshalDataBag returned an error: %v", err)
+	}
+
+	if bag.Hostname == nil || *bag.Hostname != "example.com" {
+		t.Errorf("Expected hostname %q, got %v", "example.com", bag.Hostname)
+	}
+}
+
+func TestMarshalDataBag_OmitEmptyClearsKey(t *testing.T) {
+	data, err := goops.MarshalDataBag(optionalDataBag{})
+	if err != nil {
+		t.Fatalf("MarshalDataBag returned an error: %v", err)
+	}
+
+	for _, key := range []string{"path", "port"} {
+		value, ok := data[key]
+		if !ok || value != "" {
+			t.Errorf("Expected %q to be sent with an empty value, got %q (present: %v)", key, value, ok)
+		}
+	}
+
+	var bag optionalDataBag
+
+	err = goops.UnmarshalDataBag(data, &bag)
+	if err != nil {
+		t.Fatalf("UnmarshalDataBag returned an error: %v", err)
+	}
+
+	if bag.Hostname != nil || bag.Path != "" || bag.Port != 0 {
+		t.Errorf("Expected empty values to leave fields untouched, got %+v", bag)
+	}
+}
+
+func TestUnmarshalDataBag(t *testing.T) {
+	var bag exampleDataBag
+
+	err := goops.UnmarshalDataBag(map[string]string{
+		"hostname": "example.com",
+		"port":     "443",
+		"tags":     `["a","b"]`,
+	}, &bag)
+	if err != nil {
+		t.Fatalf("UnmarshalDataBag returned an error: %v", err)
+	}
+
+	if bag.Hostname != "example.com" || bag.Port != 443 || len(bag.Tags) != 2 || bag.Enabled {
+		t.Errorf("Unexpected decoded databag: %+v", bag)
+	}
+}
+
+func TestUnmarshalDataBag_FieldErrors(t *testing.T) {
+	var bag exampleDataBag
+
+	err := goops.UnmarshalDataBag(map[string]string{
+		"hostname": "example.com",
+		"port":     "not-a-number",
+		"enabled":  "maybe",
+	}, &bag)
+
+	var decodeErr *goops.DataBagDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected a DataBagDecodeError, got %v", err)
+	}
+
+	if len(decodeErr.Fields) != 2 {
+		t.Fatalf("Expected 2 field errors, got %d", len(decodeErr.Fields))
+	}
+
+	if decodeErr.Fields[0].Key != "port" || decodeErr.Fields[1].Key != "enabled" {
+		t.Errorf("Unexpected field errors: %v", decodeErr)
+	}
+
+	if bag.Hostname != "example.com" {
+		t.Errorf("Expected valid fields to be decoded, got %+v", bag)
+	}
+}
+
+func TestGetAppRelationDataAs_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"hostname":"example.com","port":"443","enabled":"true"}`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	result, err := goops.GetAppRelationDataAs[exampleDataBag]("db:0", "postgres/0")
+	if err != nil {
+		t.Fatalf("GetAppRelationDataAs returned an error: %v", err)
+	}
+
+	if result.Hostname != "example.com" || result.Port != 443 || !result.Enabled {
+		t.Errorf("Unexpected relation data: %+v", result)
+	}
+
+	if fakeRunner.Command != "relation-get" {
+		t.Errorf("Expected command %q, got %q", "relation-get", fakeRunner.Command)
+	}
+}
+
+func TestGetUnitRelationDataAsWithClient_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"hostname":"example.com","port":"443"}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	result, err := goops.GetUnitRelationDataAsWithClient[exampleDataBag](client, "db:0", "postgres/0")
+	if err != nil {
+		t.Fatalf("GetUnitRelationDataAsWithClient returned an error: %v", err)
+	}
+
+	if result.Hostname != "example.com" || result.Port != 443 {
+		t.Errorf("Unexpected relation data: %+v", result)
+	}
+
+	if fakeRunner.Command != "relation-get" {
+		t.Errorf("Expected command %q, got %q", "relation-get", fakeRunner.Command)
+	}
+}
+
+func TestSetAppRelationDataFrom_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	err := goops.SetAppRelationDataFrom("db:0", exampleDataBag{
+		Hostname: "example.com",
+		Tags:     []string{"a"},
+	})
+	if err != nil {
+		t.Fatalf("SetAppRelationDataFrom returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "relation-set" {
+		t.Errorf("Expected command %q, got %q", "relation-set", fakeRunner.Command)
+	}
+
+	expectedArgs := map[string]bool{
+		"-r=db:0":              true,
+		"--app":                true,
+		"hostname=example.com": true,
+		"port=0":               true,
+		"enabled=false":        true,
+		`tags=["a"]`:           true,
+	}
+
+	if len(fakeRunner.Args) != len(expectedArgs) {
+		t.Fatalf("Expected %d arguments, got %d: %v", len(expectedArgs), len(fakeRunner.Args), fakeRunner.Args)
+	}
+
+	for _, arg := range fakeRunner.Args {
+		if !expectedArgs[arg] {
+			t.Errorf("Unexpected argument %q", arg)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package goops
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type EventKind string
+
+const (
+	EventInstall               EventKind = "install"
+	EventStart                 EventKind = "start"
+	EventStop                  EventKind = "stop"
+	EventRemove                EventKind = "remove"
+	EventConfigChanged         EventKind = "config-changed"
+	EventUpdateStatus          EventKind = "update-status"
+	EventUpgradeCharm          EventKind = "upgrade-charm"
+	EventLeaderElected         EventKind = "leader-elected"
+	EventLeaderSettingsChanged EventKind = "leader-settings-changed"
+	EventPreSeriesUpgrade      EventKind = "pre-series-upgrade"
+	EventPostSeriesUpgrade     EventKind = "post-series-upgrade"
+	EventCollectMetrics        EventKind = "collect-metrics"
+	EventSecretChanged         EventKind = "secret-changed"
+	EventSecretExpired         EventKind = "secret-expired"
+	EventSecretRemove          EventKind = "secret-remove"
+	EventSecretRotate          EventKind = "secret-rotate"
+	EventRelationCreated       EventKind = "relation-created"
+	EventRelationJoined        EventKind = "relation-joined"
+	EventRelationChanged       EventKind = "relation-changed"
+	EventRelationDeparted      EventKind = "relation-departed"
+	EventRelationBroken        EventKind = "relation-broken"
+	EventPebbleReady           EventKind = "pebble-ready"
+	EventPebbleCustomNotice    EventKind = "pebble-custom-notice"
+	EventPebbleCheckFailed     EventKind = "pebble-check-failed"
+	EventPebbleCheckRecovered  EventKind = "pebble-check-recovered"
+	EventStorageAttached       EventKind = "storage-attached"
+	EventStorageDetaching      EventKind = "storage-detaching"
+	EventAction                EventKind = "action"
+	EventUnknown               EventKind = "unknown"
+)
+
+var simpleEventKinds = []EventKind{
+	EventInstall,
+	EventStart,
+	EventStop,
+	EventRemove,
+	EventConfigChanged,
+	EventUpdateStatus,
+	EventUpgradeCharm,
+	EventLeaderElected,
+	EventLeaderSettingsChanged,
+	EventPreSeriesUpgrade,
+	EventPostSeriesUpgrade,
+	EventCollectMetrics,
+	EventSecretChanged,
+	EventSecretExpired,
+	EventSecretRemove,
+	EventSecretRotate,
+}
+
+// prefixedEventKinds are events whose hook name is "<name>-<kind>",
+// where name is a relation endpoint, a container or a storage.
+var prefixedEventKinds = []EventKind{
+	EventRelationCreated,
+	EventRelationJoined,
+	EventRelationChanged,
+	EventRelationDeparted,
+	EventRelationBroken,
+	EventPebbleReady,
+	EventPebbleCustomNotice,
+	EventPebbleCheckFailed,
+	EventPebbleCheckRecovered,
+	EventStorageAttached,
+	EventStorageDetaching,
+}
+
+// Event is a typed representation of the hook or action being executed.
+type Event struct {
+	Kind      EventKind
+	Name      string // Raw hook or action name
+	Endpoint  string // Set for relation events
+	Container string // Set for pebble events
+	Storage   string // Set for storage events
+	Action    string // Set for action events
+}
+
+// Target returns the relation endpoint, container or storage name the event is about.
+// It returns an empty string for events that are not scoped to one of them.
+func (e Event) Target() string {
+	switch {
+	case e.Endpoint != "":
+		return e.Endpoint
+	case e.Container != "":
+		return e.Container
+	default:
+		return e.Storage
+	}
+}
+
+// ParseHookName parses a JUJU_HOOK_NAME value into a typed Event.
+// Hook names that are not recognised are returned with the EventUnknown kind.
+func ParseHookName(name string) Event {
+	event := Event{
+		Kind: EventUnknown,
+		Name: name,
+	}
+
+	for _, kind := range simpleEventKinds {
+		if name == string(kind) {
+			event.Kind = kind
+			return event
+		}
+	}
+
+	for _, kind := range prefixedEventKinds {
+		target, found := strings.CutSuffix(name, "-"+string(kind))
+		if !found || target == "" {
+			continue
+		}
+
+		event.Kind = kind
+
+		switch {
+		case strings.HasPrefix(string(kind), "relation-"):
+			event.Endpoint = target
+		case strings.HasPrefix(string(kind), "pebble-"):
+			event.Container = target
+		default:
+			event.Storage = target
+		}
+
+		return event
+	}
+
+	return event
+}
+
+// ReadEvent returns the event being executed, based on the JUJU_ACTION_NAME and JUJU_HOOK_NAME environment variables.
+func (c *Client) ReadEvent() Event {
+	env := c.ReadEnv()
+
+	if env.ActionName != "" {
+		return Event{
+			Kind:   EventAction,
+			Name:   env.ActionName,
+			Action: env.ActionName,
+		}
+	}
+
+	return ParseHookName(env.HookName)
+}
+
+// ReadEvent returns the event being executed, based on the JUJU_ACTION_NAME and JUJU_HOOK_NAME environment variables.
+func ReadEvent() Event {
+	return defaultClient.ReadEvent()
+}
+
+type EventHandler func(Event) error
+
+type targetedEventKey struct {
+	kind   EventKind
+	target string
+}
+
+// Dispatcher routes the current hook or action to the handlers registered for it.
+type Dispatcher struct {
+	client         *Client
+	kindHandlers   map[EventKind]EventHandler
+	targetHandlers map[targetedEventKey]EventHandler
+	actionHandlers map[string]EventHandler
+	reconcile      EventHandler
+	migrations     []Migration
+}
+
+// WithDispatcherClient sets the client the dispatcher reads the event from and logs to.
+func WithDispatcherClient(client *Client) func(*Dispatcher) {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// NewDispatcher creates a Dispatcher using the default client.
+func NewDispatcher(opts ...func(*Dispatcher)) *Dispatcher {
+	d := &Dispatcher{
+		client:         defaultClient,
+		kindHandlers:   make(map[EventKind]EventHandler),
+		targetHandlers: make(map[targetedEventKey]EventHandler),
+		actionHandlers: make(map[string]EventHandler),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// On registers a handler for every event of the given kind.
+func (d *Dispatcher) On(kind EventKind, handler EventHandler) {
+	d.kindHandlers[kind] = handler
+}
+
+// OnEndpoint registers a handler for events of the given kind on a specific target.
+// The target is the relation endpoint for relation events, the container name for
+// pebble events and the storage name for storage events.
+// Handlers registered with OnEndpoint take precedence over those registered with On.
+func (d *Dispatcher) OnEndpoint(kind EventKind, target string, handler EventHandler) {
+	d.targetHandlers[targetedEventKey{kind: kind, target: target}] = handler
+}
+
+// OnAction registers a handler for the named action.
+func (d *Dispatcher) OnAction(name string, handler EventHandler) {
+	d.actionHandlers[name] = handler
+}
+
+// Reconcile registers the fallback handler, run for hooks that have no specific handler.
+func (d *Dispatcher) Reconcile(handler EventHandler) {
+	d.reconcile = handler
+}
+
+// Migrations registers the stored state migrations of the charm.
+// Before any handler runs, migrations newer than the recorded schema version are
+// applied, so stored state is migrated on upgrade-charm or, if that hook was missed,
+// on the first hook after the upgrade. On install there is no state to migrate and
+// the latest version is recorded directly.
+func (d *Dispatcher) Migrations(migrations ...Migration) {
+	d.migrations = append(d.migrations, migrations...)
+}
+
+func (d *Dispatcher) migrateState(event Event) error {
+	if len(d.migrations) == 0 {
+		return nil
+	}
+
+	if event.Kind == EventInstall {
+		return d.client.SetStateSchemaVersion(latestMigrationVersion(d.migrations))
+	}
+
+	return d.client.MigrateState(d.migrations...)
+}
+
+func (d *Dispatcher) handlerFor(event Event) EventHandler {
+	if event.Kind == EventAction {
+		return d.actionHandlers[event.Action]
+	}
+
+	if handler, ok := d.targetHandlers[targetedEventKey{kind: event.Kind, target: event.Target()}]; ok {
+		return handler
+	}
+
+	if handler, ok := d.kindHandlers[event.Kind]; ok {
+		return handler
+	}
+
+	return d.reconcile
+}
+
+// Run dispatches the current event to its handler and returns the handler error.
+// Registered stored state migrations run first. Once the handler succeeds, the statuses collected with AddUnitStatus and
+// AddAppStatus are committed. Nothing is dispatched when the process is not
+// running in a hook or action. A panicking handler or migration is recovered and reported as an error.
+func (d *Dispatcher) Run() (err error) {
+	event := d.client.ReadEvent()
+	if event.Name == "" {
+		return nil
+	}
+
+	if event.Kind == EventAction {
+		d.client.LogInfof("Action name: %s", event.Action)
+	} else {
+		d.client.LogInfof("Hook name: %s", event.Name)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while handling %s: %v", event.Name, r)
+		}
+	}()
+
+	err = d.migrateState(event)
+	if err != nil {
+		return err
+	}
+
+	handler := d.handlerFor(event)
+	if handler == nil {
+		if event.Kind == EventAction {
+			return fmt.Errorf("action %q not recognized", event.Action)
+		}
+
+		return nil
+	}
+
+	err = handler(event)
+	if err != nil {
+		return fmt.Errorf("failed to handle %s: %w", event.Name, err)
+	}
+
+	err = d.client.CommitStatus()
+	if err != nil {
+		return fmt.Errorf("failed to commit status after %s: %w", event.Name, err)
+	}
+
+	return nil
+}
+
+// Main runs the dispatcher and exits the process with a non-zero code if the handler failed.
+// It is meant to be the body of a charm's main function.
+func (d *Dispatcher) Main() {
+	err := d.Run()
+	if err != nil {
+		d.client.LogErrorf("%s", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,185 @@
+package goops_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestParseHookName(t *testing.T) {
+	tests := []struct {
+		hookName string
+		expected goops.Event
+	}{
+		{
+			hookName: "install",
+			expected: goops.Event{Kind: goops.EventInstall, Name: "install"},
+		},
+		{
+			hookName: "config-changed",
+			expected: goops.Event{Kind: goops.EventConfigChanged, Name: "config-changed"},
+		},
+		{
+			hookName: "secret-rotate",
+			expected: goops.Event{Kind: goops.EventSecretRotate, Name: "secret-rotate"},
+		},
+		{
+			hookName: "example-peers-relation-changed",
+			expected: goops.Event{Kind: goops.EventRelationChanged, Name: "example-peers-relation-changed", Endpoint: "example-peers"},
+		},
+		{
+			hookName: "certificates-relation-broken",
+			expected: goops.Event{Kind: goops.EventRelationBroken, Name: "certificates-relation-broken", Endpoint: "certificates"},
+		},
+		{
+			hookName: "notary-pebble-ready",
+			expected: goops.Event{Kind: goops.EventPebbleReady, Name: "notary-pebble-ready", Container: "notary"},
+		},
+		{
+			hookName: "database-storage-attached",
+			expected: goops.Event{Kind: goops.EventStorageAttached, Name: "database-storage-attached", Storage: "database"},
+		},
+		{
+			hookName: "something-else",
+			expected: goops.Event{Kind: goops.EventUnknown, Name: "something-else"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.hookName, func(t *testing.T) {
+			event := goops.ParseHookName(tc.hookName)
+			if event != tc.expected {
+				t.Errorf("Expected event %+v, got %+v", tc.expected, event)
+			}
+		})
+	}
+}
+
+func newDispatcherForHook(hookName string, actionName string) *goops.Dispatcher {
+	client := goops.NewClient(
+		goops.WithCommandRunner(&FakeRunner{}),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{
+				"JUJU_HOOK_NAME":   hookName,
+				"JUJU_ACTION_NAME": actionName,
+			},
+		}),
+	)
+
+	return goops.NewDispatcher(goops.WithDispatcherClient(client))
+}
+
+func TestDispatcherRun_EndpointHandlerTakesPrecedence(t *testing.T) {
+	dispatcher := newDispatcherForHook("certificates-relation-changed", "")
+
+	var called string
+
+	dispatcher.On(goops.EventRelationChanged, func(goops.Event) error {
+		called = "kind"
+		return nil
+	})
+	dispatcher.OnEndpoint(goops.EventRelationChanged, "certificates", func(event goops.Event) error {
+		called = "endpoint:" + event.Endpoint
+		return nil
+	})
+	dispatcher.Reconcile(func(goops.Event) error {
+		called = "reconcile"
+		return nil
+	})
+
+	err := dispatcher.Run()
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	if called != "endpoint:certificates" {
+		t.Errorf("Expected endpoint handler to be called, got %q", called)
+	}
+}
+
+func TestDispatcherRun_FallsBackToReconcile(t *testing.T) {
+	dispatcher := newDispatcherForHook("update-status", "")
+
+	var called string
+
+	dispatcher.On(goops.EventInstall, func(goops.Event) error {
+		called = "install"
+		return nil
+	})
+	dispatcher.Reconcile(func(event goops.Event) error {
+		called = "reconcile:" + string(event.Kind)
+		return nil
+	})
+
+	err := dispatcher.Run()
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	if called != "reconcile:update-status" {
+		t.Errorf("Expected reconcile handler to be called, got %q", called)
+	}
+}
+
+func TestDispatcherRun_Action(t *testing.T) {
+	dispatcher := newDispatcherForHook("", "get-ca-certificate")
+
+	var called string
+
+	dispatcher.OnAction("get-ca-certificate", func(event goops.Event) error {
+		called = event.Action
+		return nil
+	})
+
+	err := dispatcher.Run()
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	if called != "get-ca-certificate" {
+		t.Errorf("Expected action handler to be called, got %q", called)
+	}
+}
+
+func TestDispatcherRun_UnknownAction(t *testing.T) {
+	dispatcher := newDispatcherForHook("", "unknown-action")
+
+	err := dispatcher.Run()
+	if err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+}
+
+func TestDispatcherRun_HandlerError(t *testing.T) {
+	dispatcher := newDispatcherForHook("install", "")
+
+	handlerErr := errors.New("boom")
+
+	dispatcher.On(goops.EventInstall, func(goops.Event) error {
+		return handlerErr
+	})
+
+	err := dispatcher.Run()
+	if !errors.Is(err, handlerErr) {
+		t.Fatalf("Expected error to wrap %v, got %v", handlerErr, err)
+	}
+}
+
+func TestDispatcherRun_HandlerPanic(t *testing.T) {
+	dispatcher := newDispatcherForHook("install", "")
+
+	dispatcher.On(goops.EventInstall, func(goops.Event) error {
+		panic("unexpected")
+	})
+
+	err := dispatcher.Run()
+	if err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "panic while handling install: unexpected") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
@@ -2,10 +2,10 @@ package goops
 
 import (
 	"os"
+	"strconv"
+	"strings"
 )
 
-var defaultGetter EnvironmentGetter = &realExecutionEnvironment{}
-
 type realExecutionEnvironment struct{}
 
 type Environment struct {
@@ -21,14 +21,29 @@ type Environment struct {
 	CharmNoProxy       string
 	CloudAPIVersion    string
 	ContextID          string
+	DepartingUnit      string
+	DispatchPath       string
 	HookName           string
 	MachineID          string
 	ModelName          string
 	ModelUUID          string
+	NoticeID           string
+	NoticeKey          string
+	NoticeType         string
 	Path               string
+	PebbleCheckName    string
 	PrincipalUnit      string
+	Relation           string
+	RelationID         string
+	RemoteApp          string
+	RemoteUnit         string
+	SecretID           string
+	SecretLabel        string
+	SecretRevision     string
+	StorageID          string
 	UnitName           string
 	Version            string
+	WorkloadName       string
 }
 
 type EnvironmentGetter interface {
@@ -48,17 +63,19 @@ type JujuEnvironment struct {
 	Getter EnvironmentGetter
 }
 
+// GetEnvGetter returns the environment getter of the default client.
 func GetEnvGetter() EnvironmentGetter {
-	return defaultGetter
+	return defaultClient.envGetter
 }
 
+// SetEnvGetter replaces the environment getter of the default client.
 func SetEnvGetter(envGetter EnvironmentGetter) {
-	defaultGetter = envGetter
+	defaultClient.envGetter = envGetter
 }
 
 // ReadEnv reads the Juju related environment variables and returns an Environment struct
-func ReadEnv() Environment {
-	envGetter := GetEnvGetter()
+func (c *Client) ReadEnv() Environment {
+	envGetter := c.envGetter
 
 	return Environment{
 		ActionName:         envGetter.Get("JUJU_ACTION_NAME"),
@@ -73,13 +90,144 @@ func ReadEnv() Environment {
 		CharmHTTPSProxy:    envGetter.Get("JUJU_CHARM_HTTPS_PROXY"),
 		CharmNoProxy:       envGetter.Get("JUJU_CHARM_NO_PROXY"),
 		ContextID:          envGetter.Get("JUJU_CONTEXT_ID"),
+		DepartingUnit:      envGetter.Get("JUJU_DEPARTING_UNIT"),
+		DispatchPath:       envGetter.Get("JUJU_DISPATCH_PATH"),
 		HookName:           envGetter.Get("JUJU_HOOK_NAME"),
 		MachineID:          envGetter.Get("JUJU_MACHINE_ID"),
 		ModelName:          envGetter.Get("JUJU_MODEL_NAME"),
 		ModelUUID:          envGetter.Get("JUJU_MODEL_UUID"),
+		NoticeID:           envGetter.Get("JUJU_NOTICE_ID"),
+		NoticeKey:          envGetter.Get("JUJU_NOTICE_KEY"),
+		NoticeType:         envGetter.Get("JUJU_NOTICE_TYPE"),
+		PebbleCheckName:    envGetter.Get("JUJU_PEBBLE_CHECK_NAME"),
 		PrincipalUnit:      envGetter.Get("JUJU_PRINCIPAL_UNIT"),
+		Relation:           envGetter.Get("JUJU_RELATION"),
+		RelationID:         envGetter.Get("JUJU_RELATION_ID"),
+		RemoteApp:          envGetter.Get("JUJU_REMOTE_APP"),
+		RemoteUnit:         envGetter.Get("JUJU_REMOTE_UNIT"),
+		SecretID:           envGetter.Get("JUJU_SECRET_ID"),
+		SecretLabel:        envGetter.Get("JUJU_SECRET_LABEL"),
+		SecretRevision:     envGetter.Get("JUJU_SECRET_REVISION"),
+		StorageID:          envGetter.Get("JUJU_STORAGE_ID"),
 		UnitName:           envGetter.Get("JUJU_UNIT_NAME"),
 		Version:            envGetter.Get("JUJU_VERSION"),
+		WorkloadName:       envGetter.Get("JUJU_WORKLOAD_NAME"),
 		Path:               envGetter.Get("PATH"),
 	}
 }
+
+// ReadEnv reads the Juju related environment variables and returns an Environment struct
+func ReadEnv() Environment {
+	return defaultClient.ReadEnv()
+}
+
+// RelationEvent describes the relation that triggered a relation hook.
+type RelationEvent struct {
+	Name          string // Relation endpoint name
+	ID            string
+	RemoteApp     string
+	RemoteUnit    string
+	DepartingUnit string // Only set in relation-departed hooks
+}
+
+// RelationEvent returns the relation that triggered the current hook.
+// The boolean is false when the hook is not a relation hook.
+func (e Environment) RelationEvent() (RelationEvent, bool) {
+	if e.RelationID == "" {
+		return RelationEvent{}, false
+	}
+
+	return RelationEvent{
+		Name:          e.Relation,
+		ID:            e.RelationID,
+		RemoteApp:     e.RemoteApp,
+		RemoteUnit:    e.RemoteUnit,
+		DepartingUnit: e.DepartingUnit,
+	}, true
+}
+
+// SecretEvent describes the secret that triggered a secret hook.
+type SecretEvent struct {
+	ID       string
+	Label    string
+	Revision int // Only set in secret-remove and secret-expired hooks
+}
+
+// SecretEvent returns the secret that triggered the current hook.
+// The boolean is false when the hook is not a secret hook.
+func (e Environment) SecretEvent() (SecretEvent, bool) {
+	if e.SecretID == "" {
+		return SecretEvent{}, false
+	}
+
+	revision, _ := strconv.Atoi(e.SecretRevision)
+
+	return SecretEvent{
+		ID:       e.SecretID,
+		Label:    e.SecretLabel,
+		Revision: revision,
+	}, true
+}
+
+// StorageEvent describes the storage instance that triggered a storage hook.
+type StorageEvent struct {
+	ID   string // Storage instance ID, for example "data/0"
+	Name string // Storage name, for example "data"
+}
+
+// StorageEvent returns the storage instance that triggered the current hook.
+// The boolean is false when the hook is not a storage hook.
+func (e Environment) StorageEvent() (StorageEvent, bool) {
+	if e.StorageID == "" {
+		return StorageEvent{}, false
+	}
+
+	name, _, _ := strings.Cut(e.StorageID, "/")
+
+	return StorageEvent{
+		ID:   e.StorageID,
+		Name: name,
+	}, true
+}
+
+// WorkloadEvent describes the workload container that triggered a pebble hook.
+type WorkloadEvent struct {
+	Name      string
+	CheckName string // Only set in pebble-check-failed and pebble-check-recovered hooks
+}
+
+// WorkloadEvent returns the workload container that triggered the current hook.
+// The boolean is false when the hook is not a pebble hook.
+func (e Environment) WorkloadEvent() (WorkloadEvent, bool) {
+	if e.WorkloadName == "" {
+		return WorkloadEvent{}, false
+	}
+
+	return WorkloadEvent{
+		Name:      e.WorkloadName,
+		CheckName: e.PebbleCheckName,
+	}, true
+}
+
+// NoticeEvent describes the Pebble notice that triggered a pebble-custom-notice hook.
+type NoticeEvent struct {
+	WorkloadName string
+	ID           string
+	Type         string
+	Key          string
+}
+
+// NoticeEvent returns the Pebble notice that triggered the current hook.
+// The boolean is false when the hook is not a pebble-custom-notice hook.
+func (e Environment) NoticeEvent() (NoticeEvent, bool) {
+	if e.NoticeID == "" {
+		return NoticeEvent{}, false
+	}
+
+	return NoticeEvent{
+		WorkloadName: e.WorkloadName,
+		ID:           e.NoticeID,
+		Type:         e.NoticeType,
+		Key:          e.NoticeKey,
+	}, true
+}
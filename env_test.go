@@ -0,0 +1,117 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestReadEnv_RelationEvent(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{
+			"JUJU_HOOK_NAME":      "certificates-relation-departed",
+			"JUJU_RELATION":       "certificates",
+			"JUJU_RELATION_ID":    "certificates:3",
+			"JUJU_REMOTE_APP":     "requirer",
+			"JUJU_REMOTE_UNIT":    "requirer/1",
+			"JUJU_DEPARTING_UNIT": "requirer/1",
+		},
+	}))
+
+	relationEvent, ok := client.ReadEnv().RelationEvent()
+	if !ok {
+		t.Fatalf("Expected a relation event")
+	}
+
+	expected := goops.RelationEvent{
+		Name:          "certificates",
+		ID:            "certificates:3",
+		RemoteApp:     "requirer",
+		RemoteUnit:    "requirer/1",
+		DepartingUnit: "requirer/1",
+	}
+	if relationEvent != expected {
+		t.Errorf("Expected relation event %+v, got %+v", expected, relationEvent)
+	}
+
+	if _, ok := client.ReadEnv().SecretEvent(); ok {
+		t.Errorf("Expected no secret event")
+	}
+}
+
+func TestReadEnv_SecretEvent(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{
+			"JUJU_HOOK_NAME":       "secret-remove",
+			"JUJU_SECRET_ID":       "secret:123",
+			"JUJU_SECRET_LABEL":    "my-label",
+			"JUJU_SECRET_REVISION": "4",
+		},
+	}))
+
+	secretEvent, ok := client.ReadEnv().SecretEvent()
+	if !ok {
+		t.Fatalf("Expected a secret event")
+	}
+
+	expected := goops.SecretEvent{
+		ID:       "secret:123",
+		Label:    "my-label",
+		Revision: 4,
+	}
+	if secretEvent != expected {
+		t.Errorf("Expected secret event %+v, got %+v", expected, secretEvent)
+	}
+}
+
+func TestReadEnv_StorageEvent(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{
+			"JUJU_HOOK_NAME":  "database-storage-attached",
+			"JUJU_STORAGE_ID": "database/2",
+		},
+	}))
+
+	storageEvent, ok := client.ReadEnv().StorageEvent()
+	if !ok {
+		t.Fatalf("Expected a storage event")
+	}
+
+	if storageEvent.ID != "database/2" || storageEvent.Name != "database" {
+		t.Errorf("Unexpected storage event %+v", storageEvent)
+	}
+}
+
+func TestReadEnv_NoticeEvent(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{
+			"JUJU_HOOK_NAME":     "notary-pebble-custom-notice",
+			"JUJU_WORKLOAD_NAME": "notary",
+			"JUJU_NOTICE_ID":     "1",
+			"JUJU_NOTICE_TYPE":   "custom",
+			"JUJU_NOTICE_KEY":    "example.com/backup",
+		},
+	}))
+
+	env := client.ReadEnv()
+
+	workloadEvent, ok := env.WorkloadEvent()
+	if !ok || workloadEvent.Name != "notary" {
+		t.Errorf("Unexpected workload event %+v", workloadEvent)
+	}
+
+	noticeEvent, ok := env.NoticeEvent()
+	if !ok {
+		t.Fatalf("Expected a notice event")
+	}
+
+	expected := goops.NoticeEvent{
+		WorkloadName: "notary",
+		ID:           "1",
+		Type:         "custom",
+		Key:          "example.com/backup",
+	}
+	if noticeEvent != expected {
+		t.Errorf("Expected notice event %+v, got %+v", expected, noticeEvent)
+	}
+}
@@ -0,0 +1,127 @@
+package goops
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Sentinel errors returned, possibly wrapped, by the hook tool wrappers.
+// Use errors.Is to check for them.
+var (
+	ErrToolNotFound     = errors.New("hook tool not found")
+	ErrNotLeader        = errors.New("unit is not the leader")
+	ErrNotRunningAction = errors.New("not running an action")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSecretNotFound   = errors.New("secret not found")
+	ErrRelationNotFound = errors.New("relation not found")
+	ErrResourceNotFound = errors.New("resource not found")
+	ErrStateNotFound    = errors.New("no state found")
+	ErrNotStorageHook   = errors.New("not running a storage hook")
+	ErrNotTrusted       = errors.New("application is not trusted")
+	ErrUnsupported      = errors.New("not supported by this Juju version")
+)
+
+// hookToolErrorPatterns maps sentinel errors to the substrings of the hook tool
+// error output that identify them. Every substring of an entry must match.
+var hookToolErrorPatterns = []struct {
+	err        error
+	substrings []string
+}{
+	{err: ErrNotLeader, substrings: []string{"not the leader"}},
+	{err: ErrNotRunningAction, substrings: []string{"not running an action"}},
+	{err: ErrPermissionDenied, substrings: []string{"permission denied"}},
+	{err: ErrSecretNotFound, substrings: []string{"secret", "not found"}},
+	{err: ErrRelationNotFound, substrings: []string{"relation not found"}},
+	{err: ErrResourceNotFound, substrings: []string{"resource", "not found"}},
+	{err: ErrNotTrusted, substrings: []string{"cannot access cloud credentials"}},
+}
+
+// sensitiveArgTools lists the hook tools whose key=value arguments carry values that must not be logged.
+var sensitiveArgTools = map[string]bool{
+	"secret-add": true,
+	"secret-set": true,
+}
+
+const redacted = "<redacted>"
+
+// HookToolError is returned when a hook tool fails.
+// Args holds the arguments of the call, with sensitive values redacted.
+// ExitCode is -1 when the tool could not be started.
+type HookToolError struct {
+	Tool     string
+	Args     []string
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+// NewHookToolError creates a HookToolError, redacting sensitive values from args.
+// It is meant for CommandRunner implementations.
+func NewHookToolError(tool string, args []string, exitCode int, stderr string, err error) *HookToolError {
+	return &HookToolError{
+		Tool:     tool,
+		Args:     redactArgs(tool, args),
+		ExitCode: exitCode,
+		Stderr:   strings.TrimSpace(stderr),
+		Err:      err,
+	}
+}
+
+func (e *HookToolError) Error() string {
+	if e.Stderr == "" && e.Err != nil {
+		return fmt.Sprintf("command %s failed: %v", e.Tool, e.Err)
+	}
+
+	return fmt.Sprintf("command %s failed: %s", e.Tool, e.Stderr)
+}
+
+func (e *HookToolError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error output of the tool identifies it as the target sentinel error.
+func (e *HookToolError) Is(target error) bool {
+	if target == ErrToolNotFound {
+		return errors.Is(e.Err, exec.ErrNotFound)
+	}
+
+	for _, pattern := range hookToolErrorPatterns {
+		if pattern.err != target {
+			continue
+		}
+
+		for _, substring := range pattern.substrings {
+			if !strings.Contains(e.Stderr, substring) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// redactArgs returns a copy of args where the values of key=value arguments of
+// tools handling secret content are replaced.
+func redactArgs(tool string, args []string) []string {
+	redactedArgs := append([]string(nil), args...)
+
+	if !sensitiveArgTools[tool] {
+		return redactedArgs
+	}
+
+	for i, arg := range redactedArgs {
+		if strings.HasPrefix(arg, "--") {
+			continue
+		}
+
+		if key, _, found := strings.Cut(arg, "="); found {
+			redactedArgs[i] = key + "=" + redacted
+		}
+	}
+
+	return redactedArgs
+}
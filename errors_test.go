@@ -0,0 +1,89 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestHookToolError_Is(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		stderr   string
+		sentinel error
+	}{
+		{name: "SecretNotFound", tool: "secret-get", stderr: `ERROR secret "12345" not found`, sentinel: goops.ErrSecretNotFound},
+		{name: "NotLeader", tool: "status-get", stderr: "ERROR finding application status: this unit is not the leader", sentinel: goops.ErrNotLeader},
+		{name: "NotRunningAction", tool: "action-get", stderr: "ERROR not running an action", sentinel: goops.ErrNotRunningAction},
+		{name: "RelationNotFound", tool: "relation-get", stderr: `ERROR invalid value "certificates:0" for option -r: relation not found`, sentinel: goops.ErrRelationNotFound},
+		{name: "PermissionDenied", tool: "secret-get", stderr: "ERROR permission denied", sentinel: goops.ErrPermissionDenied},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRunner := &FakeRunner{
+				Err: goops.NewHookToolError(tc.tool, nil, 1, tc.stderr+"\n", nil),
+			}
+
+			goops.SetCommandRunner(fakeRunner)
+
+			_, err := goops.GetSecretByID("12345", false, false)
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("Expected error to match %v, got %v", tc.sentinel, err)
+			}
+
+			var hookToolErr *goops.HookToolError
+			if !errors.As(err, &hookToolErr) {
+				t.Fatalf("Expected a HookToolError, got %v", err)
+			}
+
+			if hookToolErr.Stderr != tc.stderr {
+				t.Errorf("Expected stderr %q, got %q", tc.stderr, hookToolErr.Stderr)
+			}
+		})
+	}
+}
+
+func TestHookToolError_ToolNotFound(t *testing.T) {
+	client := goops.NewClient()
+
+	_, err := client.IsLeader()
+	if !errors.Is(err, goops.ErrToolNotFound) {
+		t.Fatalf("Expected error to match %v, got %v", goops.ErrToolNotFound, err)
+	}
+
+	var hookToolErr *goops.HookToolError
+	if !errors.As(err, &hookToolErr) {
+		t.Fatalf("Expected a HookToolError, got %v", err)
+	}
+
+	if hookToolErr.Tool != "is-leader" || hookToolErr.ExitCode != -1 {
+		t.Errorf("Unexpected error: %+v", hookToolErr)
+	}
+}
+
+func TestNewHookToolError_RedactsSecretContent(t *testing.T) {
+	err := goops.NewHookToolError("secret-add", []string{"--label=db", "password=hunter2"}, 1, "", nil)
+
+	expected := []string{"--label=db", "password=<redacted>"}
+	for i, arg := range expected {
+		if err.Args[i] != arg {
+			t.Errorf("Expected argument %q, got %q", arg, err.Args[i])
+		}
+	}
+}
+
+func TestGetState_NotFound(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`""`),
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	_, err := goops.GetState("key")
+	if !errors.Is(err, goops.ErrStateNotFound) {
+		t.Fatalf("Expected error to match %v, got %v", goops.ErrStateNotFound, err)
+	}
+}
@@ -1,15 +1,45 @@
 package goops_test
 
+import (
+	"os"
+	"strings"
+)
+
 type FakeRunner struct {
-	Command string
-	Args    []string
-	Output  []byte
-	Err     error
+	Command     string
+	Args        []string
+	Output      []byte
+	Err         error
+	FileContent []byte
 }
 
 func (f *FakeRunner) Run(name string, args ...string) ([]byte, error) {
 	f.Command = name
 	f.Args = args
 
+	for _, arg := range args {
+		if path, ok := strings.CutPrefix(arg, "--file="); ok {
+			f.FileContent, _ = os.ReadFile(path)
+		}
+	}
+
 	return f.Output, f.Err
 }
+
+type FakeEnvGetter struct {
+	Env   map[string]string
+	Files map[string][]byte
+}
+
+func (f *FakeEnvGetter) Get(name string) string {
+	return f.Env[name]
+}
+
+func (f *FakeEnvGetter) ReadFile(name string) ([]byte, error) {
+	data, ok := f.Files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return data, nil
+}
@@ -19,8 +19,8 @@ type GoalState struct {
 }
 
 // GetGoalState retrieves the status of the charm's peers and related units.
-func GetGoalState() (*GoalState, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetGoalState() (*GoalState, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--format=json"}
 
@@ -38,3 +38,8 @@ func GetGoalState() (*GoalState, error) {
 
 	return &goalState, nil
 }
+
+// GetGoalState retrieves the status of the charm's peers and related units.
+func GetGoalState() (*GoalState, error) {
+	return defaultClient.GetGoalState()
+}
@@ -0,0 +1,112 @@
+package goopstest_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+type RotateCertificateParams struct {
+	ValidityDays int    `json:"validity-days"`
+	Algorithm    string `json:"algorithm"`
+	Force        bool   `json:"force"`
+}
+
+var rotateCertificateSchema = goops.ActionsSchema{
+	"rotate-certificate": {
+		Params: map[string]goops.ActionParam{
+			"validity-days": {Type: "integer", Default: 90},
+			"algorithm":     {Type: "string", Enum: []any{"rsa", "ecdsa"}},
+			"force":         {Type: "boolean", Default: false},
+		},
+		Required: []string{"algorithm"},
+	},
+}
+
+func RotateCertificate(params RotateCertificateParams) (map[string]string, error) {
+	if params.Force {
+		return nil, errors.New("forced rotation is disabled")
+	}
+
+	return map[string]string{
+		"algorithm":     params.Algorithm,
+		"validity-days": strconv.Itoa(params.ValidityDays),
+	}, nil
+}
+
+func RegisteredActionCharm() error {
+	dispatcher := goops.NewDispatcher()
+	goops.RegisterAction(dispatcher, "rotate-certificate", RotateCertificate)
+
+	return dispatcher.Run()
+}
+
+func TestRegisterAction(t *testing.T) {
+	ctx := goopstest.NewContext(RegisteredActionCharm, goopstest.WithActionsSchema(rotateCertificateSchema))
+
+	_, err := ctx.RunAction("rotate-certificate", goopstest.State{}, map[string]any{
+		"algorithm": "ecdsa",
+	})
+	if err != nil {
+		t.Fatalf("RunAction returned an error: %v", err)
+	}
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if ctx.ActionError != nil {
+		t.Fatalf("expected action to succeed, got: %v", ctx.ActionError)
+	}
+
+	if ctx.ActionResults["algorithm"] != "ecdsa" {
+		t.Errorf("got ActionResults[algorithm]=%q, want ecdsa", ctx.ActionResults["algorithm"])
+	}
+
+	if ctx.ActionResults["validity-days"] != "90" {
+		t.Errorf("got ActionResults[validity-days]=%q, want default of 90", ctx.ActionResults["validity-days"])
+	}
+}
+
+func TestRegisterActionInvalidParams(t *testing.T) {
+	ctx := goopstest.NewContext(RegisteredActionCharm, goopstest.WithActionsSchema(rotateCertificateSchema))
+
+	_, err := ctx.RunAction("rotate-certificate", goopstest.State{}, map[string]any{
+		"algorithm": "dsa",
+	})
+	if err != nil {
+		t.Fatalf("RunAction returned an error: %v", err)
+	}
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	expectedErr := "invalid parameters for action rotate-certificate: algorithm: value dsa is not one of [rsa ecdsa]"
+	if ctx.ActionError == nil || ctx.ActionError.Error() != expectedErr {
+		t.Errorf("got ActionError=%v, want %q", ctx.ActionError, expectedErr)
+	}
+}
+
+func TestRegisterActionHandlerError(t *testing.T) {
+	ctx := goopstest.NewContext(RegisteredActionCharm, goopstest.WithActionsSchema(rotateCertificateSchema))
+
+	_, err := ctx.RunAction("rotate-certificate", goopstest.State{}, map[string]any{
+		"algorithm": "rsa",
+		"force":     true,
+	})
+	if err != nil {
+		t.Fatalf("RunAction returned an error: %v", err)
+	}
+
+	if ctx.ActionError == nil || ctx.ActionError.Error() != "forced rotation is disabled" {
+		t.Errorf("got ActionError=%v, want forced rotation is disabled", ctx.ActionError)
+	}
+
+	if len(ctx.ActionResults) != 0 {
+		t.Errorf("expected no action results, got %v", ctx.ActionResults)
+	}
+}
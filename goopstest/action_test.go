@@ -335,3 +335,51 @@ func TestSetActionResultsInNonActionHook(t *testing.T) {
 		t.Errorf("got CharmErr=%q, want 'failed to set action parameters: command action-set failed: ERROR not running an action'", ctx.CharmErr.Error())
 	}
 }
+
+type CertificateResult struct {
+	CommonName string `json:"common-name"`
+	Days       int    `json:"days"`
+}
+
+type CertificateResults struct {
+	Certificates []CertificateResult `json:"certificates"`
+}
+
+func SetNestedActionResults() error {
+	return goops.SetActionResultsFrom(CertificateResults{
+		Certificates: []CertificateResult{
+			{CommonName: "example.com", Days: 90},
+		},
+	})
+}
+
+func TestSetActionResultsFromNested(t *testing.T) {
+	ctx := goopstest.NewContext(SetNestedActionResults)
+
+	_, err := ctx.RunAction("list-certificates", goopstest.State{}, nil)
+	if err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if ctx.ActionResults["certificates.0.common-name"] != "example.com" {
+		t.Errorf("got ActionResults=%v, want certificates.0.common-name=example.com", ctx.ActionResults)
+	}
+
+	certificates, ok := ctx.NestedActionResults["certificates"].(map[string]any)
+	if !ok {
+		t.Fatalf("got NestedActionResults=%v, want certificates map", ctx.NestedActionResults)
+	}
+
+	first, ok := certificates["0"].(map[string]any)
+	if !ok {
+		t.Fatalf("got certificates=%v, want entry 0", certificates)
+	}
+
+	if first["common-name"] != "example.com" || first["days"] != "90" {
+		t.Errorf("got certificate=%v, want common-name=example.com and days=90", first)
+	}
+}
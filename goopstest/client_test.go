@@ -0,0 +1,64 @@
+package goopstest_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func ActiveIfLeaderClient(client *goops.Client) error {
+	isLeader, err := client.IsLeader()
+	if err != nil {
+		return err
+	}
+
+	if isLeader {
+		return client.SetUnitStatus(goops.StatusActive, "leader")
+	}
+
+	return client.SetUnitStatus(goops.StatusBlocked, "not leader")
+}
+
+func TestClientContextParallel(t *testing.T) {
+	tests := []struct {
+		name               string
+		leader             bool
+		expectedStatusName goopstest.StatusName
+	}{
+		{
+			name:               "Leader",
+			leader:             true,
+			expectedStatusName: goopstest.StatusActive,
+		},
+		{
+			name:               "NonLeader",
+			leader:             false,
+			expectedStatusName: goopstest.StatusBlocked,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := goopstest.NewClientContext(ActiveIfLeaderClient)
+
+			stateIn := goopstest.State{
+				Leader: tc.leader,
+			}
+
+			for range 50 {
+				stateOut := ctx.Run("start", stateIn)
+
+				if ctx.CharmErr != nil {
+					t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+				}
+
+				if stateOut.UnitStatus.Name != tc.expectedStatusName {
+					t.Fatalf("got UnitStatus=%q, want %q", stateOut.UnitStatus.Name, tc.expectedStatusName)
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package goopstest
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gruyaume/goops"
+	"gopkg.in/yaml.v3"
 )
 
 type fakeCommandRunner struct {
@@ -20,6 +25,7 @@ type fakeCommandRunner struct {
 	Leader             bool
 	Config             map[string]any
 	Secrets            []Secret
+	ActionName         string
 	ActionResults      map[string]string
 	ActionParameters   map[string]any
 	ActionError        error
@@ -27,12 +33,18 @@ type fakeCommandRunner struct {
 	Relations          []Relation
 	PeerRelations      []PeerRelation
 	Ports              []Port
+	Networks           []Network
+	Storages           []Storage
+	Resources          []Resource
+	Trusted            bool
+	Credential         *goops.CloudSpec
 	StoredState        StoredState
 	AppName            string
 	UnitID             string
 	JujuLog            []JujuLogLine
 	Model              Model
 	Metadata           Metadata
+	JujuVersion        string
 }
 
 func (f *fakeCommandRunner) Run(name string, args ...string) ([]byte, error) {
@@ -54,6 +66,7 @@ func (f *fakeCommandRunner) Run(name string, args ...string) ([]byte, error) {
 		"is-leader":               f.handleIsLeader,
 		"juju-log":                f.handleJujuLog,
 		"juju-reboot":             f.handleJujuReboot,
+		"network-get":             f.handleNetworkGet,
 		"opened-ports":            f.handleOpenedPorts,
 		"open-port":               f.handleOpenPort,
 		"relation-ids":            f.handleRelationIDs,
@@ -75,14 +88,60 @@ func (f *fakeCommandRunner) Run(name string, args ...string) ([]byte, error) {
 		"state-delete":            f.handleStateDelete,
 		"status-get":              f.handleStatusGet,
 		"status-set":              f.handleStatusSet,
+		"storage-add":             f.handleStorageAdd,
+		"storage-get":             f.handleStorageGet,
+		"storage-list":            f.handleStorageList,
 	}
 
-	if handler, exists := handlers[name]; exists {
-		handler(args)
-		return f.Output, f.Err
+	handler, exists := handlers[name]
+	if !exists {
+		return nil, goops.NewHookToolError(name, args, -1, "", &exec.Error{Name: name, Err: exec.ErrNotFound})
+	}
+
+	if !f.supports(name, args) {
+		return nil, unsupportedError(name, args)
+	}
+
+	handler(args)
+
+	if f.Err != nil {
+		return f.Output, hookToolError(name, args, f.Err)
+	}
+
+	return f.Output, nil
+}
+
+// supports reports whether the simulated Juju version provides the hook tool call.
+// Unparseable versions support every call, like the goops client does.
+func (f *fakeCommandRunner) supports(name string, args []string) bool {
+	feature, ok := goops.RequiredFeature(name, args...)
+	if !ok {
+		return true
+	}
+
+	version, err := goops.ParseJujuVersion(f.JujuVersion)
+	if err != nil {
+		return true
 	}
 
-	return nil, fmt.Errorf("unknown command: %s", name)
+	return version.Supports(feature)
+}
+
+// unsupportedError returns the error an older Juju agent produces for a call it does not provide:
+// the tool is missing, or, for open-port, close-port and opened-ports, the --endpoints flag is unknown.
+func unsupportedError(name string, args []string) *goops.HookToolError {
+	if name == "open-port" || name == "close-port" || name == "opened-ports" {
+		return hookToolError(name, args, fmt.Errorf("ERROR flag provided but not defined: -endpoints"))
+	}
+
+	return goops.NewHookToolError(name, args, -1, "", &exec.Error{Name: name, Err: exec.ErrNotFound})
+}
+
+// hookToolError converts an error raised by a fake hook tool into the error the real tool would produce.
+func hookToolError(name string, args []string, err error) *goops.HookToolError {
+	stderr := strings.TrimPrefix(err.Error(), "command "+name+" failed: ")
+
+	return goops.NewHookToolError(name, args, 1, stderr, nil)
 }
 
 type AppStatus struct {
@@ -176,10 +235,73 @@ func (f *fakeCommandRunner) handleIsLeader(_ []string) {
 	}
 }
 
-func (f *fakeCommandRunner) handleOpenedPorts(_ []string) {
+func (f *fakeCommandRunner) handleNetworkGet(args []string) {
+	meta, remaining := splitPrefixedArgs(args, "-")
+	relationID := meta["r"]
+
+	if len(remaining) == 0 {
+		f.Err = fmt.Errorf("command network-get failed: ERROR no arguments specified")
+		return
+	}
+
+	binding := remaining[0]
+
+	network := findNetwork(f.Networks, binding, relationID)
+	if network == nil {
+		f.Err = fmt.Errorf("command network-get failed: ERROR no network config found for binding %q", binding)
+		return
+	}
+
+	output, err := json.Marshal(goops.Network{
+		BindAddresses:    network.BindAddresses,
+		IngressAddresses: network.IngressAddresses,
+		EgressSubnets:    network.EgressSubnets,
+	})
+	if err != nil {
+		f.Err = fmt.Errorf("failed to marshal network: %w", err)
+		return
+	}
+
+	f.Output = output
+}
+
+// findNetwork returns the network of the binding for the relation, falling back
+// to the network of the binding that is not specific to a relation.
+func findNetwork(networks []Network, binding string, relationID string) *Network {
+	var fallback *Network
+
+	for i, network := range networks {
+		if network.Binding != binding {
+			continue
+		}
+
+		if relationID != "" && network.RelationID == relationID {
+			return &networks[i]
+		}
+
+		if network.RelationID == "" && fallback == nil {
+			fallback = &networks[i]
+		}
+	}
+
+	return fallback
+}
+
+func (f *fakeCommandRunner) handleOpenedPorts(args []string) {
+	showEndpoints := slices.Contains(args, "--endpoints")
+
 	portList := make([]string, len(f.Ports))
 	for i, port := range f.Ports {
-		portList[i] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		portList[i] = portRangeString(port)
+
+		if showEndpoints {
+			endpoints := "*"
+			if len(port.Endpoints) > 0 {
+				endpoints = strings.Join(port.Endpoints, ",")
+			}
+
+			portList[i] += " (" + endpoints + ")"
+		}
 	}
 
 	output, err := json.Marshal(portList)
@@ -192,65 +314,118 @@ func (f *fakeCommandRunner) handleOpenedPorts(_ []string) {
 }
 
 func (f *fakeCommandRunner) handleOpenPort(args []string) {
-	portInfo := strings.Split(args[0], "/")
-
-	if len(portInfo) != 2 {
-		f.Err = fmt.Errorf("invalid port format, expected <port>/<protocol>")
+	port, err := parsePortArgs(args)
+	if err != nil {
+		f.Err = fmt.Errorf("command open-port failed: ERROR %w", err)
 		return
 	}
 
-	port, err := strconv.Atoi(portInfo[0])
-	if err != nil || port < 0 || port > 65535 {
-		f.Err = fmt.Errorf("invalid port number: %s", portInfo[0])
-		return
+	for _, p := range f.Ports {
+		if samePortRange(p, port) && slices.Equal(sortedEndpoints(p), sortedEndpoints(port)) {
+			return
+		}
 	}
 
-	protocol := portInfo[1]
+	f.Ports = append(f.Ports, port)
+}
 
-	if protocol != "tcp" && protocol != "udp" {
-		f.Err = fmt.Errorf("invalid protocol: %s, must be 'tcp' or 'udp'", protocol)
+// handleClosePort removes the matching port range. Without --endpoints, the range
+// is closed for every endpoint it was opened for.
+func (f *fakeCommandRunner) handleClosePort(args []string) {
+	port, err := parsePortArgs(args)
+	if err != nil {
+		f.Err = fmt.Errorf("command close-port failed: ERROR %w", err)
 		return
 	}
 
+	ports := make([]Port, 0, len(f.Ports))
+
 	for _, p := range f.Ports {
-		if p.Port == port && p.Protocol == protocol {
-			return
+		if samePortRange(p, port) && (len(port.Endpoints) == 0 || slices.Equal(sortedEndpoints(p), sortedEndpoints(port))) {
+			continue
 		}
+
+		ports = append(ports, p)
 	}
 
-	f.Ports = append(f.Ports, Port{
-		Port:     port,
-		Protocol: protocol,
-	})
+	f.Ports = ports
 }
 
-func (f *fakeCommandRunner) handleClosePort(args []string) {
-	portInfo := strings.Split(args[0], "/")
+// parsePortArgs parses the arguments of open-port and close-port, for example
+// 80/tcp, 8000-8100/udp or icmp, optionally followed by --endpoints=<endpoint>,...
+func parsePortArgs(args []string) (Port, error) {
+	meta, remaining := splitPrefixedArgs(args, "--")
 
-	if len(portInfo) != 2 {
-		f.Err = fmt.Errorf("invalid port format, expected <port>/<protocol>")
-		return
+	if len(remaining) != 1 {
+		return Port{}, fmt.Errorf("expected a single port argument, got %d", len(remaining))
 	}
 
-	port, err := strconv.Atoi(portInfo[0])
-	if err != nil || port < 0 || port > 65535 {
-		f.Err = fmt.Errorf("invalid port number: %s", portInfo[0])
-		return
+	var port Port
+
+	if meta["endpoints"] != "" {
+		port.Endpoints = strings.Split(meta["endpoints"], ",")
+	}
+
+	if remaining[0] == "icmp" {
+		port.Protocol = "icmp"
+		return port, nil
 	}
 
-	protocol := portInfo[1]
+	portRange, protocol, found := strings.Cut(remaining[0], "/")
+	if !found {
+		return Port{}, fmt.Errorf("invalid port format, expected <port>[-<port>]/<protocol>")
+	}
 
 	if protocol != "tcp" && protocol != "udp" {
-		f.Err = fmt.Errorf("invalid protocol: %s, must be 'tcp' or 'udp'", protocol)
-		return
+		return Port{}, fmt.Errorf("invalid protocol: %s, must be 'tcp' or 'udp'", protocol)
 	}
 
-	for i, p := range f.Ports {
-		if p.Port == port && p.Protocol == protocol {
-			f.Ports = append(f.Ports[:i], f.Ports[i+1:]...)
-			return
+	port.Protocol = protocol
+
+	from, to, isRange := strings.Cut(portRange, "-")
+
+	number, err := strconv.Atoi(from)
+	if err != nil || number < 0 || number > 65535 {
+		return Port{}, fmt.Errorf("invalid port number: %s", from)
+	}
+
+	port.Port = number
+
+	if isRange {
+		number, err = strconv.Atoi(to)
+		if err != nil || number < port.Port || number > 65535 {
+			return Port{}, fmt.Errorf("invalid port range: %s", portRange)
 		}
+
+		if number != port.Port {
+			port.EndPort = number
+		}
+	}
+
+	return port, nil
+}
+
+func portRangeString(port Port) string {
+	if port.Protocol == "icmp" {
+		return "icmp"
+	}
+
+	if port.EndPort != 0 && port.EndPort != port.Port {
+		return fmt.Sprintf("%d-%d/%s", port.Port, port.EndPort, port.Protocol)
 	}
+
+	return fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+}
+
+func samePortRange(a Port, b Port) bool {
+	return portRangeString(a) == portRangeString(b)
+}
+
+func sortedEndpoints(port Port) []string {
+	endpoints := slices.Clone(port.Endpoints)
+	slices.Sort(endpoints)
+
+	return endpoints
 }
 
 func (f *fakeCommandRunner) handleConfigGet(_ []string) {
@@ -268,8 +443,26 @@ func (f *fakeCommandRunner) handleConfigGet(_ []string) {
 	f.Output = output
 }
 
+// handleCredentialGet returns the cloud spec of the state, which requires the
+// application to be trusted. Without a cloud spec, an empty object is returned.
 func (f *fakeCommandRunner) handleCredentialGet(_ []string) {
-	f.Output = []byte(`{}`)
+	if f.Credential == nil {
+		f.Output = []byte(`{}`)
+		return
+	}
+
+	if !f.Trusted {
+		f.Err = fmt.Errorf("command credential-get failed: ERROR cannot access cloud credentials: application is not trusted, run juju trust")
+		return
+	}
+
+	output, err := json.Marshal(f.Credential)
+	if err != nil {
+		f.Err = fmt.Errorf("failed to marshal cloud spec: %w", err)
+		return
+	}
+
+	f.Output = output
 }
 
 type goalStateStatusContents struct {
@@ -561,6 +754,8 @@ func (f *fakeCommandRunner) handleRelationList(args []string) {
 }
 
 func parseRelationSetArgs(args []string) (isApp bool, relationID string, data map[string]string, err error) {
+	var file string
+
 	filteredArgs := make([]string, 0, len(args))
 
 	for _, arg := range args {
@@ -569,6 +764,8 @@ func parseRelationSetArgs(args []string) (isApp bool, relationID string, data ma
 			isApp = true
 		case strings.HasPrefix(arg, "-r="):
 			relationID = strings.TrimPrefix(arg, "-r=")
+		case strings.HasPrefix(arg, "--file="):
+			file = strings.TrimPrefix(arg, "--file=")
 		default:
 			filteredArgs = append(filteredArgs, arg)
 		}
@@ -580,6 +777,11 @@ func parseRelationSetArgs(args []string) (isApp bool, relationID string, data ma
 
 	data = parseKeyValueArgs(filteredArgs)
 
+	err = readContentFile(file, data)
+	if err != nil {
+		return false, "", nil, fmt.Errorf("command relation-set failed: ERROR %w", err)
+	}
+
 	return isApp, relationID, data, nil
 }
 
@@ -609,6 +811,11 @@ func (f *fakeCommandRunner) handleRelationSet(args []string) {
 		}
 
 		for k, v := range data {
+			if v == "" {
+				delete(*target, k)
+				continue
+			}
+
 			(*target)[k] = v
 		}
 	}
@@ -669,6 +876,14 @@ func (f *fakeCommandRunner) handleRelationModelGet(args []string) {
 
 func (f *fakeCommandRunner) handleResourceGet(args []string) {
 	requestedResourceName := args[0]
+
+	for _, resource := range f.Resources {
+		if resource.Name == requestedResourceName {
+			f.Output = []byte(resource.Path + "\n")
+			return
+		}
+	}
+
 	appName := f.AppName
 	unitID := f.UnitID
 	unitNumber := strings.Split(unitID, "/")[1]
@@ -706,6 +921,16 @@ func (f *fakeCommandRunner) handleSecretAdd(args []string) {
 
 	content := parseKeyValueArgs(remaining)
 
+	err = readContentFile(meta["file"], content)
+	if err == nil {
+		err = decodeBase64Content(content)
+	}
+
+	if err != nil {
+		f.Err = fmt.Errorf("command secret-add failed: ERROR %w", err)
+		return
+	}
+
 	f.Secrets = append(f.Secrets, Secret{
 		Label:       label,
 		Content:     content,
@@ -752,6 +977,11 @@ func (f *fakeCommandRunner) handleSecretGet(args []string) {
 		return
 	}
 
+	if secret.Owner == "user" && !secret.Granted {
+		f.Err = fmt.Errorf("ERROR permission denied")
+		return
+	}
+
 	f.setSecretOutput(secret)
 }
 
@@ -935,6 +1165,16 @@ func (f *fakeCommandRunner) handleSecretSet(args []string) {
 		content[parts[0]] = parts[1]
 	}
 
+	err := readContentFile(meta["file"], content)
+	if err == nil {
+		err = decodeBase64Content(content)
+	}
+
+	if err != nil {
+		f.Err = fmt.Errorf("command secret-set failed: ERROR %w", err)
+		return
+	}
+
 	for _, secret := range f.Secrets {
 		if secret.ID != id {
 			continue
@@ -979,6 +1219,53 @@ func (f *fakeCommandRunner) handleSecretSet(args []string) {
 	f.Err = fmt.Errorf("secret with ID %q not found", id)
 }
 
+// readContentFile adds the key values of the YAML file passed with --file to content.
+func readContentFile(path string, content map[string]string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read content file: %w", err)
+	}
+
+	var fileContent map[string]string
+
+	err = yaml.Unmarshal(data, &fileContent)
+	if err != nil {
+		return fmt.Errorf("cannot parse content file: %w", err)
+	}
+
+	for key, value := range fileContent {
+		content[key] = value
+	}
+
+	return nil
+}
+
+// decodeBase64Content decodes the values of secret content keys suffixed with #base64,
+// storing them under the key without the suffix.
+func decodeBase64Content(content map[string]string) error {
+	for key, value := range content {
+		name, ok := strings.CutSuffix(key, "#base64")
+		if !ok {
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("invalid base64 value for key %q: %w", name, err)
+		}
+
+		delete(content, key)
+
+		content[name] = string(decoded)
+	}
+
+	return nil
+}
+
 func parseRFC3339(s string) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, nil
@@ -992,6 +1279,19 @@ func (f *fakeCommandRunner) handleSecretRevoke(args []string) {}
 func (f *fakeCommandRunner) handleStateGet(args []string) {
 	key := args[0]
 
+	if strings.HasPrefix(key, "--") {
+		output, err := json.Marshal(f.StoredState)
+		if err != nil {
+			f.Err = fmt.Errorf("failed to marshal state: %w", err)
+
+			return
+		}
+
+		f.Output = output
+
+		return
+	}
+
 	if f.StoredState == nil {
 		f.Output = []byte(`""`)
 
@@ -1065,7 +1365,7 @@ func parseKeyValueArgs(args []string) map[string]string {
 }
 
 func (f *fakeCommandRunner) handleActionSet(args []string) {
-	if goops.ReadEnv().ActionName == "" {
+	if f.ActionName == "" {
 		f.Err = fmt.Errorf("command action-set failed: ERROR not running an action")
 		return
 	}
@@ -1074,14 +1374,14 @@ func (f *fakeCommandRunner) handleActionSet(args []string) {
 }
 
 func (f *fakeCommandRunner) handleActionLog(_ []string) {
-	if goops.ReadEnv().ActionName == "" {
+	if f.ActionName == "" {
 		f.Err = fmt.Errorf("command action-log failed: ERROR not running an action")
 		return
 	}
 }
 
 func (f *fakeCommandRunner) handleActionFail(args []string) {
-	if goops.ReadEnv().ActionName == "" {
+	if f.ActionName == "" {
 		f.Err = fmt.Errorf("command action-fail failed: ERROR not running an action")
 		return
 	}
@@ -1090,7 +1390,7 @@ func (f *fakeCommandRunner) handleActionFail(args []string) {
 }
 
 func (f *fakeCommandRunner) handleActionGet(_ []string) {
-	if goops.ReadEnv().ActionName == "" {
+	if f.ActionName == "" {
 		f.Err = fmt.Errorf("command action-get failed: ERROR not running an action")
 		return
 	}
@@ -1111,3 +1411,134 @@ func (f *fakeCommandRunner) handleActionGet(_ []string) {
 func (f *fakeCommandRunner) handleApplicationVersionSet(args []string) {
 	f.ApplicationVersion = args[0]
 }
+
+func (f *fakeCommandRunner) handleStorageAdd(args []string) {
+	for _, arg := range args {
+		name, countString, found := strings.Cut(arg, "=")
+
+		count := 1
+
+		if found {
+			var err error
+
+			count, err = strconv.Atoi(countString)
+			if err != nil || count < 1 {
+				f.Err = fmt.Errorf("command storage-add failed: ERROR invalid storage count %q", countString)
+				return
+			}
+		}
+
+		meta, declared := f.Metadata.Storage[name]
+		if f.Metadata.Storage != nil && !declared {
+			f.Err = fmt.Errorf("command storage-add failed: ERROR storage %q not found", name)
+			return
+		}
+
+		kind := meta.Type
+		if kind == "" {
+			kind = "filesystem"
+		}
+
+		for range count {
+			id := fmt.Sprintf("%s/%d", name, nextStorageIndex(f.Storages, name))
+
+			location := meta.Location
+			if location == "" {
+				location = "/var/lib/juju/storage/" + id
+			}
+
+			f.Storages = append(f.Storages, Storage{
+				ID:       id,
+				Kind:     kind,
+				Location: location,
+			})
+		}
+	}
+}
+
+func (f *fakeCommandRunner) handleStorageGet(args []string) {
+	var id string
+
+	for i, arg := range args {
+		if arg == "-s" && i+1 < len(args) {
+			id = args[i+1]
+		}
+	}
+
+	if id == "" {
+		f.Err = fmt.Errorf("command storage-get failed: ERROR no storage instance specified")
+		return
+	}
+
+	storage := findStorage(f.Storages, id)
+	if storage == nil {
+		f.Err = fmt.Errorf("command storage-get failed: ERROR invalid value %q for option -s: storage instance %q not found", id, id)
+		return
+	}
+
+	output, err := json.Marshal(map[string]string{
+		"kind":     storage.Kind,
+		"location": storage.Location,
+	})
+	if err != nil {
+		f.Err = fmt.Errorf("failed to marshal storage: %w", err)
+		return
+	}
+
+	f.Output = output
+}
+
+func (f *fakeCommandRunner) handleStorageList(args []string) {
+	_, remaining := splitPrefixedArgs(args, "--")
+
+	var name string
+	if len(remaining) > 0 {
+		name = remaining[0]
+	}
+
+	ids := make([]string, 0, len(f.Storages))
+
+	for _, storage := range f.Storages {
+		storageName, _, _ := strings.Cut(storage.ID, "/")
+		if name == "" || storageName == name {
+			ids = append(ids, storage.ID)
+		}
+	}
+
+	output, err := json.Marshal(ids)
+	if err != nil {
+		f.Err = fmt.Errorf("failed to marshal storage list: %w", err)
+		return
+	}
+
+	f.Output = output
+}
+
+func findStorage(storages []Storage, id string) *Storage {
+	for i := range storages {
+		if storages[i].ID == id {
+			return &storages[i]
+		}
+	}
+
+	return nil
+}
+
+// nextStorageIndex returns the index of the next instance of the named storage.
+func nextStorageIndex(storages []Storage, name string) int {
+	next := 0
+
+	for _, storage := range storages {
+		storageName, indexString, _ := strings.Cut(storage.ID, "/")
+		if storageName != name {
+			continue
+		}
+
+		index, err := strconv.Atoi(indexString)
+		if err == nil && index >= next {
+			next = index + 1
+		}
+	}
+
+	return next
+}
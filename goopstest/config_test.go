@@ -1,6 +1,7 @@
 package goopstest_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -132,3 +133,145 @@ func TestActiveIfExpectedConfigInActionHook(t *testing.T) {
 		t.Errorf("Expected UnitStatus %q, got %q", goopstest.StatusActive, stateOut.UnitStatus)
 	}
 }
+
+type SchemaConfig struct {
+	CACommonName string `json:"ca-common-name"`
+	ValidityDays int    `json:"validity-days"`
+}
+
+func ConfigMatchingSchema() error {
+	schema, err := goops.ReadConfigSchema()
+	if err != nil {
+		return err
+	}
+
+	config := SchemaConfig{}
+
+	err = schema.Validate(&config)
+	if err != nil {
+		return err
+	}
+
+	err = goops.GetConfig(&config)
+	if err != nil {
+		return err
+	}
+
+	_ = goops.SetUnitStatus(goops.StatusActive, fmt.Sprintf("%s valid for %d days", config.CACommonName, config.ValidityDays))
+
+	return nil
+}
+
+func TestConfigSchemaDefaults(t *testing.T) {
+	schema, err := goops.ParseConfigSchema([]byte(`
+config:
+  options:
+    ca-common-name:
+      type: string
+      default: self-signed-certificates
+    validity-days:
+      type: int
+      default: 365
+`))
+	if err != nil {
+		t.Fatalf("failed to parse config schema: %v", err)
+	}
+
+	ctx := goopstest.NewContext(ConfigMatchingSchema, goopstest.WithConfigSchema(schema))
+
+	stateIn := goopstest.State{
+		Config: map[string]any{
+			"validity-days": 30,
+		},
+	}
+
+	stateOut := ctx.Run("config-changed", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	expectedStatus := goopstest.Status{
+		Name:    goopstest.StatusActive,
+		Message: "self-signed-certificates valid for 30 days",
+	}
+
+	if stateOut.UnitStatus != expectedStatus {
+		t.Errorf("got UnitStatus=%v, want %v", stateOut.UnitStatus, expectedStatus)
+	}
+}
+
+type SecretConfigCharmConfig struct {
+	DatabasePassword goops.SecretConfig `json:"database-password"`
+}
+
+func ReadSecretConfig() error {
+	config := SecretConfigCharmConfig{}
+
+	err := goops.GetConfig(&config)
+	if err != nil {
+		return err
+	}
+
+	if config.DatabasePassword.Content["password"] != "super-secret" {
+		return fmt.Errorf("unexpected secret content: %v", config.DatabasePassword.Content)
+	}
+
+	return nil
+}
+
+func TestSecretConfig(t *testing.T) {
+	ctx := goopstest.NewContext(ReadSecretConfig)
+
+	stateIn := goopstest.State{
+		Config: map[string]any{
+			"database-password": "secret:cvh7kruupa1s46bqvuig",
+		},
+		Secrets: []goopstest.Secret{
+			{
+				ID:      "secret:cvh7kruupa1s46bqvuig",
+				Owner:   "user",
+				Granted: true,
+				Content: map[string]string{"password": "super-secret"},
+			},
+		},
+	}
+
+	_ = ctx.Run("config-changed", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+}
+
+func TestSecretConfigNotGranted(t *testing.T) {
+	ctx := goopstest.NewContext(ReadSecretConfig)
+
+	stateIn := goopstest.State{
+		Config: map[string]any{
+			"database-password": "secret:cvh7kruupa1s46bqvuig",
+		},
+		Secrets: []goopstest.Secret{
+			{
+				ID:      "secret:cvh7kruupa1s46bqvuig",
+				Owner:   "user",
+				Content: map[string]string{"password": "super-secret"},
+			},
+		},
+	}
+
+	_ = ctx.Run("config-changed", stateIn)
+
+	var secretConfigErr *goops.SecretConfigError
+	if !errors.As(ctx.CharmErr, &secretConfigErr) {
+		t.Fatalf("expected a SecretConfigError, got: %v", ctx.CharmErr)
+	}
+
+	if secretConfigErr.Option != "database-password" {
+		t.Errorf("got Option=%q, want database-password", secretConfigErr.Option)
+	}
+
+	if secretConfigErr.SecretID != "secret:cvh7kruupa1s46bqvuig" {
+		t.Errorf("got SecretID=%q, want secret:cvh7kruupa1s46bqvuig", secretConfigErr.SecretID)
+	}
+}
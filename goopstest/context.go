@@ -2,6 +2,8 @@ package goopstest
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gruyaume/goops"
 )
@@ -24,51 +26,47 @@ type JujuLogLine struct {
 	Message string
 }
 
-type MountMeta struct {
-	Location string
-	Storage  string
-}
-
-type ContainerMeta struct {
-	Mounts   []MountMeta
-	Resource string
-}
-
-type IntegrationMeta struct {
-	Interface string
-}
-
-type ResourceMeta struct {
-	Description string
-	Type        string
-	Filename    string
-}
-
-type StorageMeta struct {
-	MinimumSize string
-	Type        string
-}
-
-type Metadata struct {
-	Containers  map[string]ContainerMeta
-	Description string
-	Name        string
-	Provides    map[string]IntegrationMeta
-	Resources   map[string]ResourceMeta
-	Storage     map[string]StorageMeta
-	Summary     string
-}
+// The charm metadata model is shared with goops, so that tests and charms use the same definition.
+type (
+	Metadata        = goops.Metadata
+	ContainerMeta   = goops.Container
+	MountMeta       = goops.Mount
+	IntegrationMeta = goops.Integration
+	ResourceMeta    = goops.Resource
+	StorageMeta     = goops.Storage
+	DeviceMeta      = goops.Device
+)
 
 type Context struct {
-	CharmFunc     func() error
-	Metadata      Metadata
-	AppName       string
-	UnitID        string
-	JujuVersion   string
-	ActionResults map[string]string
-	ActionError   error
-	JujuLog       []JujuLogLine
-	CharmErr      error
+	CharmFunc       func() error
+	ClientCharmFunc func(*goops.Client) error
+	Metadata        Metadata
+	AppName         string
+	UnitID          string
+	JujuVersion     string
+	ActionResults   map[string]string
+	ActionError     error
+	JujuLog         []JujuLogLine
+	CharmErr        error
+	// JujuLogRecords lists the records written through a goops.SlogHandler during the last run,
+	// with their attributes. Each of them is also in JujuLog, rendered as Juju receives it.
+	JujuLogRecords []goops.LogRecord
+	// NestedActionResults holds ActionResults with dotted keys expanded into
+	// nested maps, as Juju renders them to the user.
+	NestedActionResults map[string]any
+	// ConfigSchema holds the config options of the charm. When set, options
+	// missing from State.Config are given their default value, as Juju does.
+	ConfigSchema *goops.ConfigSchema
+	// ActionsSchema holds the actions of the charm, against which actions
+	// registered with goops.RegisterAction validate their parameters.
+	ActionsSchema goops.ActionsSchema
+	// InvalidRelationData lists relation data that failed its interface schema during the last run.
+	// Writing invalid data also sets CharmErr.
+	InvalidRelationData []*goops.RelationDataValidationError
+	// UnitStatusCandidates and AppStatusCandidates list the statuses the charm
+	// added with goops.AddUnitStatus and goops.AddAppStatus during the last run.
+	UnitStatusCandidates []Status
+	AppStatusCandidates  []Status
 }
 
 func WithUnitID(id string) func(*Context) {
@@ -95,11 +93,41 @@ func WithMetadata(meta Metadata) func(*Context) {
 	}
 }
 
+func WithConfigSchema(schema *goops.ConfigSchema) func(*Context) {
+	return func(c *Context) {
+		c.ConfigSchema = schema
+	}
+}
+
+func WithActionsSchema(schema goops.ActionsSchema) func(*Context) {
+	return func(c *Context) {
+		c.ActionsSchema = schema
+	}
+}
+
+// NewContext creates a test context for a charm written against the package-level
+// goops functions. Each run installs a fake goops client as the default client,
+// so tests using such contexts must not run in parallel.
 func NewContext(charm func() error, opts ...func(*Context)) *Context {
 	ctx := &Context{
 		CharmFunc: charm,
 	}
 
+	return setContextDefaults(ctx, opts...)
+}
+
+// NewClientContext creates a test context for a charm that receives a *goops.Client.
+// Each run hands the charm its own fake client and leaves the default client
+// untouched, so tests using such contexts can run in parallel.
+func NewClientContext(charm func(*goops.Client) error, opts ...func(*Context)) *Context {
+	ctx := &Context{
+		ClientCharmFunc: charm,
+	}
+
+	return setContextDefaults(ctx, opts...)
+}
+
+func setContextDefaults(ctx *Context, opts ...func(*Context)) *Context {
 	for _, opt := range opts {
 		opt(ctx)
 	}
@@ -123,7 +151,32 @@ func NewContext(charm func() error, opts ...func(*Context)) *Context {
 	return ctx
 }
 
+// Event carries the event-specific context of a hook run, such as the
+// relation, secret or storage that triggered it.
+type Event struct {
+	RelationID     string
+	RemoteUnit     UnitID
+	DepartingUnit  UnitID
+	SecretID       string
+	SecretLabel    string
+	SecretRevision int
+	StorageID      string
+	WorkloadName   string
+	CheckName      string
+	NoticeID       string
+	NoticeType     string
+	NoticeKey      string
+}
+
 func (c *Context) Run(hookName string, state State) State {
+	return c.RunWithEvent(hookName, state, Event{})
+}
+
+// RunWithEvent runs the charm for the given hook with event-specific context.
+// The relation name and remote application are looked up from the state using
+// event.RelationID, the secret label from event.SecretID and the workload name
+// from the hook name when they are not set.
+func (c *Context) RunWithEvent(hookName string, state State, event Event) State {
 	state.Relations = setRelationIDs(state.Relations)
 	state.Relations = setUnitIDs(state.Relations)
 	state.PeerRelations = setPeerRelationIDs(state.PeerRelations)
@@ -147,11 +200,16 @@ func (c *Context) Run(hookName string, state State) State {
 		Output:        []byte(``),
 		Err:           nil,
 		Leader:        state.Leader,
-		Config:        state.Config,
+		Config:        c.configWithDefaults(state.Config),
 		Secrets:       state.Secrets,
 		Relations:     state.Relations,
 		PeerRelations: state.PeerRelations,
 		Ports:         state.Ports,
+		Networks:      state.Networks,
+		Storages:      state.Storages,
+		Resources:     state.Resources,
+		Trusted:       state.Trusted,
+		Credential:    state.Credential,
 		StoredState:   state.StoredState,
 		AppName:       c.AppName,
 		UnitID:        c.UnitID,
@@ -159,35 +217,32 @@ func (c *Context) Run(hookName string, state State) State {
 		UnitStatus:    state.UnitStatus,
 		AppStatus:     state.AppStatus,
 		Metadata:      c.Metadata,
+		JujuVersion:   c.JujuVersion,
 	}
 
 	fakeEnv := &fakeEnvGetter{
-		HookName:    hookName,
-		Model:       state.Model,
-		AppName:     c.AppName,
-		UnitID:      c.UnitID,
-		JujuVersion: c.JujuVersion,
-		Metadata:    c.Metadata,
+		HookName:     hookName,
+		Model:        state.Model,
+		AppName:      c.AppName,
+		UnitID:       c.UnitID,
+		JujuVersion:  c.JujuVersion,
+		Metadata:     c.Metadata,
+		ConfigSchema: c.ConfigSchema,
+		EventEnv:     c.eventEnv(hookName, state, event),
 	}
 
 	fakePebble := &fakePebbleGetter{
 		Containers: state.Containers,
 	}
 
-	goops.SetPebbleGetter(fakePebble)
-	goops.SetCommandRunner(fakeCommand)
-	goops.SetEnvGetter(fakeEnv)
-
-	err := c.CharmFunc()
-	if err != nil {
-		c.CharmErr = err
-	}
+	c.runCharm(fakeCommand, fakeEnv, fakePebble)
 
 	state.UnitStatus = fakeCommand.UnitStatus
 	state.AppStatus = fakeCommand.AppStatus
 	state.Secrets = fakeCommand.Secrets
 	state.ApplicationVersion = fakeCommand.ApplicationVersion
 	state.Ports = fakeCommand.Ports
+	state.Storages = fakeCommand.Storages
 	state.StoredState = fakeCommand.StoredState
 	state.Containers = fakePebble.Containers
 
@@ -201,18 +256,26 @@ func (c *Context) RunAction(actionName string, state State, params map[string]an
 		Output:           []byte(``),
 		Err:              nil,
 		Leader:           state.Leader,
-		Config:           state.Config,
+		Config:           c.configWithDefaults(state.Config),
 		Secrets:          state.Secrets,
 		Relations:        state.Relations,
 		PeerRelations:    state.PeerRelations,
+		ActionName:       actionName,
 		ActionParameters: params,
 		Ports:            state.Ports,
+		Networks:         state.Networks,
+		Storages:         state.Storages,
+		Resources:        state.Resources,
+		Trusted:          state.Trusted,
+		Credential:       state.Credential,
 		StoredState:      state.StoredState,
 		AppName:          c.AppName,
 		UnitID:           c.UnitID,
 		Model:            state.Model,
 		UnitStatus:       state.UnitStatus,
 		AppStatus:        state.AppStatus,
+		Metadata:         c.Metadata,
+		JujuVersion:      c.JujuVersion,
 	}
 
 	if state.Model.Name == "" {
@@ -224,31 +287,206 @@ func (c *Context) RunAction(actionName string, state State, params map[string]an
 	}
 
 	fakeEnvGetter := &fakeEnvGetter{
-		ActionName:  actionName,
-		Model:       state.Model,
-		AppName:     c.AppName,
-		UnitID:      c.UnitID,
-		JujuVersion: c.JujuVersion,
+		ActionName:    actionName,
+		Model:         state.Model,
+		AppName:       c.AppName,
+		UnitID:        c.UnitID,
+		JujuVersion:   c.JujuVersion,
+		Metadata:      c.Metadata,
+		ConfigSchema:  c.ConfigSchema,
+		ActionsSchema: c.ActionsSchema,
+		EventEnv: map[string]string{
+			"JUJU_DISPATCH_PATH": "actions/" + actionName,
+		},
 	}
 
-	goops.SetCommandRunner(fakeCommandRunner)
-	goops.SetEnvGetter(fakeEnvGetter)
-
-	err := c.CharmFunc()
-	if err != nil {
-		c.CharmErr = err
+	fakePebble := &fakePebbleGetter{
+		Containers: state.Containers,
 	}
 
+	c.runCharm(fakeCommandRunner, fakeEnvGetter, fakePebble)
+
 	state.UnitStatus = fakeCommandRunner.UnitStatus
 	state.AppStatus = fakeCommandRunner.AppStatus
 	state.Secrets = fakeCommandRunner.Secrets
 	state.ApplicationVersion = fakeCommandRunner.ApplicationVersion
 	c.ActionResults = fakeCommandRunner.ActionResults
+	c.NestedActionResults = nestActionResults(fakeCommandRunner.ActionResults)
 	c.ActionError = fakeCommandRunner.ActionError
 
 	return state, nil
 }
 
+// configWithDefaults returns the config Juju would provide: the schema defaults overridden by the given config.
+func (c *Context) configWithDefaults(config map[string]any) map[string]any {
+	if c.ConfigSchema == nil {
+		return config
+	}
+
+	merged := c.ConfigSchema.Defaults()
+	for key, value := range config {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// eventEnv returns the event-specific environment variables Juju would set for the hook.
+func (c *Context) eventEnv(hookName string, state State, event Event) map[string]string {
+	env := map[string]string{
+		"JUJU_DISPATCH_PATH": "hooks/" + hookName,
+	}
+
+	if event.RelationID != "" {
+		env["JUJU_RELATION_ID"] = event.RelationID
+
+		for _, relation := range state.Relations {
+			if relation.ID == event.RelationID {
+				env["JUJU_RELATION"] = relation.Endpoint
+				env["JUJU_REMOTE_APP"] = relation.RemoteAppName
+			}
+		}
+
+		for _, peerRelation := range state.PeerRelations {
+			if peerRelation.ID == event.RelationID {
+				env["JUJU_RELATION"] = peerRelation.Endpoint
+				env["JUJU_REMOTE_APP"] = c.AppName
+			}
+		}
+	}
+
+	if event.RemoteUnit != "" {
+		env["JUJU_REMOTE_UNIT"] = string(event.RemoteUnit)
+	}
+
+	if event.DepartingUnit != "" {
+		env["JUJU_DEPARTING_UNIT"] = string(event.DepartingUnit)
+	}
+
+	if event.SecretID != "" {
+		env["JUJU_SECRET_ID"] = event.SecretID
+		env["JUJU_SECRET_LABEL"] = event.SecretLabel
+
+		if event.SecretLabel == "" {
+			if secret := findSecretByID(state.Secrets, event.SecretID); secret != nil {
+				env["JUJU_SECRET_LABEL"] = secret.Label
+			}
+		}
+	}
+
+	if event.SecretRevision != 0 {
+		env["JUJU_SECRET_REVISION"] = strconv.Itoa(event.SecretRevision)
+	}
+
+	if event.StorageID != "" {
+		env["JUJU_STORAGE_ID"] = event.StorageID
+	}
+
+	workloadName := event.WorkloadName
+	if workloadName == "" {
+		workloadName = goops.ParseHookName(hookName).Container
+	}
+
+	if workloadName != "" {
+		env["JUJU_WORKLOAD_NAME"] = workloadName
+	}
+
+	if event.CheckName != "" {
+		env["JUJU_PEBBLE_CHECK_NAME"] = event.CheckName
+	}
+
+	if event.NoticeID != "" {
+		env["JUJU_NOTICE_ID"] = event.NoticeID
+		env["JUJU_NOTICE_TYPE"] = event.NoticeType
+		env["JUJU_NOTICE_KEY"] = event.NoticeKey
+	}
+
+	return env
+}
+
+// runCharm builds a goops client from the fakes and runs the charm against it.
+func (c *Context) runCharm(runner *fakeCommandRunner, envGetter *fakeEnvGetter, pebbleGetter *fakePebbleGetter) {
+	c.InvalidRelationData = nil
+	c.JujuLogRecords = nil
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(runner),
+		goops.WithEnvGetter(envGetter),
+		goops.WithPebbleGetter(pebbleGetter),
+		goops.WithInterfaceSchemasFrom(goops.DefaultClient()),
+		goops.WithInvalidRelationDataHandler(func(err *goops.RelationDataValidationError) {
+			c.InvalidRelationData = append(c.InvalidRelationData, err)
+		}),
+		goops.WithLogRecordHandler(func(record goops.LogRecord) {
+			c.JujuLogRecords = append(c.JujuLogRecords, record)
+		}),
+	)
+
+	var err error
+
+	if c.ClientCharmFunc != nil {
+		err = c.ClientCharmFunc(client)
+	} else {
+		goops.SetDefaultClient(client)
+
+		err = c.CharmFunc()
+	}
+
+	if err != nil {
+		c.CharmErr = err
+	}
+
+	c.UnitStatusCandidates = toStatuses(client.CollectedUnitStatuses())
+	c.AppStatusCandidates = toStatuses(client.CollectedAppStatuses())
+
+	for _, invalidData := range c.InvalidRelationData {
+		if invalidData.Write && c.CharmErr == nil {
+			c.CharmErr = fmt.Errorf("charm wrote invalid relation data: %w", invalidData)
+		}
+	}
+}
+
+// nestActionResults expands dotted action result keys into nested maps.
+func nestActionResults(results map[string]string) map[string]any {
+	if results == nil {
+		return nil
+	}
+
+	nested := make(map[string]any)
+
+	for key, value := range results {
+		parts := strings.Split(key, ".")
+		current := nested
+
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := current[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				current[part] = child
+			}
+
+			current = child
+		}
+
+		current[parts[len(parts)-1]] = value
+	}
+
+	return nested
+}
+
+func toStatuses(collected []goops.CollectedStatus) []Status {
+	var statuses []Status
+
+	for _, status := range collected {
+		statuses = append(statuses, Status{
+			Name:    StatusName(status.Name),
+			Message: status.Message,
+		})
+	}
+
+	return statuses
+}
+
 // For each relation, we set the remoteUnitsData so that it contains at leader 1 unit
 func setUnitIDs(relations []Relation) []Relation {
 	for _, relation := range relations {
@@ -1,6 +1,7 @@
 package goopstest_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -32,3 +33,69 @@ func TestGetCredential(t *testing.T) {
 		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
 	}
 }
+
+func ConfigureKubernetesClient() error {
+	spec, err := goops.GetCloudSpec()
+	if err != nil {
+		if errors.Is(err, goops.ErrNotTrusted) {
+			return goops.SetUnitStatus(goops.StatusBlocked, "run juju trust")
+		}
+
+		return err
+	}
+
+	config, err := spec.KubernetesConfig()
+	if err != nil {
+		return err
+	}
+
+	return goops.SetUnitStatus(goops.StatusActive, "connected to "+config.Host)
+}
+
+func TestGetCloudSpecTrusted(t *testing.T) {
+	ctx := goopstest.NewContext(ConfigureKubernetesClient)
+
+	stateIn := goopstest.State{
+		Trusted: true,
+		Credential: &goops.CloudSpec{
+			Type:     "kubernetes",
+			Name:     "microk8s",
+			Endpoint: "https://10.0.0.1:16443",
+			Credential: &goops.CloudCredential{
+				AuthType:   "oauth2",
+				Attributes: map[string]string{"Token": "token"},
+			},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if stateOut.UnitStatus.Message != "connected to https://10.0.0.1:16443" {
+		t.Errorf("expected status message %q, got %q", "connected to https://10.0.0.1:16443", stateOut.UnitStatus.Message)
+	}
+}
+
+func TestGetCloudSpecNotTrusted(t *testing.T) {
+	ctx := goopstest.NewContext(ConfigureKubernetesClient)
+
+	stateIn := goopstest.State{
+		Credential: &goops.CloudSpec{
+			Type: "kubernetes",
+			Name: "microk8s",
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if stateOut.UnitStatus.Name != goopstest.StatusBlocked {
+		t.Errorf("expected status %q, got %q", goopstest.StatusBlocked, stateOut.UnitStatus.Name)
+	}
+}
@@ -2,19 +2,24 @@ package goopstest
 
 import (
 	"fmt"
+	"io/fs"
 	"strings"
 
+	"github.com/gruyaume/goops"
 	"gopkg.in/yaml.v3"
 )
 
 type fakeEnvGetter struct {
-	HookName    string
-	ActionName  string
-	Model       Model
-	AppName     string
-	UnitID      string
-	JujuVersion string
-	Metadata    Metadata
+	HookName      string
+	ActionName    string
+	Model         Model
+	AppName       string
+	UnitID        string
+	JujuVersion   string
+	Metadata      Metadata
+	ConfigSchema  *goops.ConfigSchema
+	ActionsSchema goops.ActionsSchema
+	EventEnv      map[string]string
 }
 
 func (f *fakeEnvGetter) Get(key string) string {
@@ -33,7 +38,7 @@ func (f *fakeEnvGetter) Get(key string) string {
 		return f.JujuVersion
 	}
 
-	return ""
+	return f.EventEnv[key]
 }
 
 func (f *fakeEnvGetter) ReadFile(name string) ([]byte, error) {
@@ -46,5 +51,23 @@ func (f *fakeEnvGetter) ReadFile(name string) ([]byte, error) {
 		return data, nil
 	}
 
-	return nil, fmt.Errorf("file %s not found", name)
+	if strings.HasSuffix(name, "/config.yaml") && f.ConfigSchema != nil {
+		data, err := yaml.Marshal(f.ConfigSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config schema: %w", err)
+		}
+
+		return data, nil
+	}
+
+	if strings.HasSuffix(name, "/actions.yaml") && f.ActionsSchema != nil {
+		data, err := yaml.Marshal(f.ActionsSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal actions schema: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("file %s not found: %w", name, fs.ErrNotExist)
 }
@@ -92,3 +92,104 @@ func TestGetJujuVersion(t *testing.T) {
 		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
 	}
 }
+
+func RelationDepartedCharm() error {
+	relationEvent, ok := goops.ReadEnv().RelationEvent()
+	if !ok {
+		return fmt.Errorf("expected a relation event")
+	}
+
+	if relationEvent.Name != "certificates" {
+		return fmt.Errorf("expected relation name 'certificates', got '%s'", relationEvent.Name)
+	}
+
+	if relationEvent.RemoteApp != "requirer" {
+		return fmt.Errorf("expected remote app 'requirer', got '%s'", relationEvent.RemoteApp)
+	}
+
+	if relationEvent.DepartingUnit != "requirer/1" {
+		return fmt.Errorf("expected departing unit 'requirer/1', got '%s'", relationEvent.DepartingUnit)
+	}
+
+	return nil
+}
+
+func TestRunWithRelationEvent(t *testing.T) {
+	ctx := goopstest.NewContext(RelationDepartedCharm)
+
+	certificatesRelation := goopstest.Relation{
+		Endpoint:      "certificates",
+		RemoteAppName: "requirer",
+	}
+
+	stateIn := goopstest.State{
+		Relations: []goopstest.Relation{certificatesRelation},
+	}
+
+	_ = ctx.RunWithEvent("certificates-relation-departed", stateIn, goopstest.Event{
+		RelationID:    "certificates:0",
+		RemoteUnit:    "requirer/1",
+		DepartingUnit: "requirer/1",
+	})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
+
+func SecretRotateCharm() error {
+	secretEvent, ok := goops.ReadEnv().SecretEvent()
+	if !ok {
+		return fmt.Errorf("expected a secret event")
+	}
+
+	if secretEvent.Label != "my-label" {
+		return fmt.Errorf("expected secret label 'my-label', got '%s'", secretEvent.Label)
+	}
+
+	return nil
+}
+
+func TestRunWithSecretEvent(t *testing.T) {
+	ctx := goopstest.NewContext(SecretRotateCharm)
+
+	stateIn := goopstest.State{
+		Secrets: []goopstest.Secret{
+			{
+				ID:    "secret:123",
+				Label: "my-label",
+			},
+		},
+	}
+
+	_ = ctx.RunWithEvent("secret-rotate", stateIn, goopstest.Event{
+		SecretID: "secret:123",
+	})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
+
+func PebbleReadyCharm() error {
+	workloadEvent, ok := goops.ReadEnv().WorkloadEvent()
+	if !ok {
+		return fmt.Errorf("expected a workload event")
+	}
+
+	if workloadEvent.Name != "notary" {
+		return fmt.Errorf("expected workload name 'notary', got '%s'", workloadEvent.Name)
+	}
+
+	return nil
+}
+
+func TestRunPebbleReadySetsWorkloadName(t *testing.T) {
+	ctx := goopstest.NewContext(PebbleReadyCharm)
+
+	_ = ctx.Run("notary-pebble-ready", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
@@ -0,0 +1,117 @@
+package goopstest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func TestSecretConfigUnsupported(t *testing.T) {
+	ctx := goopstest.NewContext(ReadSecretConfig, goopstest.WithJujuVersion("3.3.5"))
+
+	stateIn := goopstest.State{
+		Config: map[string]any{
+			"database-password": "secret:cvh7kruupa1s46bqvuig",
+		},
+		Secrets: []goopstest.Secret{
+			{
+				ID:      "secret:cvh7kruupa1s46bqvuig",
+				Owner:   "user",
+				Granted: true,
+				Content: map[string]string{"password": "super-secret"},
+			},
+		},
+	}
+
+	_ = ctx.Run("config-changed", stateIn)
+
+	if !errors.Is(ctx.CharmErr, goops.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got: %v", ctx.CharmErr)
+	}
+}
+
+func RunSecretIDsDirectly() error {
+	_, err := goops.GetCommandRunner().Run("secret-ids", "--format=json")
+	return err
+}
+
+func TestFakeRunnerRejectsUnsupportedTool(t *testing.T) {
+	ctx := goopstest.NewContext(RunSecretIDsDirectly, goopstest.WithJujuVersion("2.9.44"))
+
+	_ = ctx.Run("start", goopstest.State{})
+
+	if !errors.Is(ctx.CharmErr, goops.ErrToolNotFound) {
+		t.Fatalf("expected ErrToolNotFound, got: %v", ctx.CharmErr)
+	}
+
+	ctx = goopstest.NewContext(RunSecretIDsDirectly, goopstest.WithJujuVersion("3.1.0"))
+
+	_ = ctx.Run("start", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+}
+
+func OpenPortOnEndpoint() error {
+	if !goops.Supports(goops.FeatureOpenPortEndpoints) {
+		return goops.OpenPort(80, "tcp")
+	}
+
+	return goops.OpenPortRange(&goops.Port{Port: 80, Protocol: "tcp", Endpoints: []string{"website"}})
+}
+
+func TestSupportsFallback(t *testing.T) {
+	ctx := goopstest.NewContext(OpenPortOnEndpoint, goopstest.WithJujuVersion("2.9.44"))
+
+	stateOut := ctx.Run("start", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Ports) != 1 {
+		t.Fatalf("expected 1 port, got %d", len(stateOut.Ports))
+	}
+
+	if len(stateOut.Ports[0].Endpoints) != 0 {
+		t.Errorf("expected the port to be opened on all endpoints, got %v", stateOut.Ports[0].Endpoints)
+	}
+}
+
+func TestSetPortsWithoutEndpointSupport(t *testing.T) {
+	ctx := goopstest.NewContext(SetPorts, goopstest.WithJujuVersion("2.9.44"))
+
+	stateIn := goopstest.State{
+		Ports: []goopstest.Port{
+			{Port: 8080, Protocol: "tcp"},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Ports) != 1 || stateOut.Ports[0].Port != 80 {
+		t.Errorf("expected only port 80 to be opened, got %v", stateOut.Ports)
+	}
+}
+
+func ListOpenedPortsDirectly() error {
+	_, err := goops.GetCommandRunner().Run("opened-ports", "--endpoints", "--format=json")
+	return err
+}
+
+func TestFakeRunnerRejectsOpenedPortsEndpoints(t *testing.T) {
+	ctx := goopstest.NewContext(ListOpenedPortsDirectly, goopstest.WithJujuVersion("2.9.44"))
+
+	_ = ctx.Run("start", goopstest.State{})
+
+	if ctx.CharmErr == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
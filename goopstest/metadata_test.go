@@ -60,3 +60,46 @@ func TestGetMetadata(t *testing.T) {
 		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
 	}
 }
+
+func GetStorageAndRequiresMetadata() error {
+	meta, err := goops.ReadMetadata()
+	if err != nil {
+		return err
+	}
+
+	if meta.Storage["database"].MinimumSize != "1G" {
+		return fmt.Errorf("expected database storage minimum size to be '1G', got '%s'", meta.Storage["database"].MinimumSize)
+	}
+
+	if meta.Requires["logging"].Limit != 1 {
+		return fmt.Errorf("expected logging relation limit to be 1, got %d", meta.Requires["logging"].Limit)
+	}
+
+	return nil
+}
+
+func TestGetMetadataStorageAndRequires(t *testing.T) {
+	ctx := goopstest.NewContext(GetStorageAndRequiresMetadata, goopstest.WithMetadata(
+		goopstest.Metadata{
+			Name: "example",
+			Storage: map[string]goopstest.StorageMeta{
+				"database": {
+					Type:        "filesystem",
+					MinimumSize: "1G",
+				},
+			},
+			Requires: map[string]goopstest.IntegrationMeta{
+				"logging": {
+					Interface: "loki_push_api",
+					Limit:     1,
+				},
+			},
+		},
+	))
+
+	_ = ctx.Run("install", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
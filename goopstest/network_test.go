@@ -0,0 +1,95 @@
+package goopstest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func AdvertiseDatabaseAddress() error {
+	for _, relationID := range []string{"database:0", "database:1"} {
+		network, err := goops.GetNetworkForRelation("database", relationID)
+		if err != nil {
+			return err
+		}
+
+		address, err := network.PrimaryIngressAddress()
+		if err != nil {
+			return err
+		}
+
+		err = goops.SetUnitRelationData(relationID, map[string]string{"address": address.String()})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestCharmGetNetworkForRelation(t *testing.T) {
+	ctx := goopstest.NewContext(AdvertiseDatabaseAddress)
+
+	stateIn := goopstest.State{
+		Relations: []goopstest.Relation{
+			{ID: "database:0", Endpoint: "database"},
+			{ID: "database:1", Endpoint: "database"},
+		},
+		Networks: []goopstest.Network{
+			{
+				Binding:          "database",
+				IngressAddresses: []string{"10.0.0.5"},
+			},
+			{
+				Binding:          "database",
+				RelationID:       "database:1",
+				IngressAddresses: []string{"203.0.113.10"},
+			},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	expected := []string{"10.0.0.5", "203.0.113.10"}
+	for i, relation := range stateOut.Relations {
+		if relation.LocalUnitData["address"] != expected[i] {
+			t.Errorf("expected address %q for relation %s, got %q", expected[i], relation.ID, relation.LocalUnitData["address"])
+		}
+	}
+}
+
+func GetUnknownNetwork() error {
+	_, err := goops.GetNetwork("unknown")
+	if err != nil {
+		return fmt.Errorf("could not get network: %w", err)
+	}
+
+	return nil
+}
+
+func TestCharmGetUnknownNetwork(t *testing.T) {
+	ctx := goopstest.NewContext(GetUnknownNetwork)
+
+	_ = ctx.Run("start", goopstest.State{})
+
+	if ctx.CharmErr == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var hookToolErr *goops.HookToolError
+	if !errors.As(ctx.CharmErr, &hookToolErr) {
+		t.Fatalf("expected a HookToolError, got %T", ctx.CharmErr)
+	}
+
+	expected := `could not get network: command network-get failed: ERROR no network config found for binding "unknown"`
+	if ctx.CharmErr.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, ctx.CharmErr.Error())
+	}
+}
@@ -186,3 +186,65 @@ func TestOpenOpenedPort(t *testing.T) {
 		t.Errorf("Expected protocol 'udp', got '%s'", stateOut.Ports[0].Protocol)
 	}
 }
+
+func SetPortRanges() error {
+	return goops.SetPorts([]*goops.Port{
+		{
+			Port:     8000,
+			EndPort:  8200,
+			Protocol: goops.ProtocolTCP,
+		},
+		{
+			Port:      9090,
+			Protocol:  goops.ProtocolTCP,
+			Endpoints: []string{"metrics-endpoint"},
+		},
+		{
+			Protocol: goops.ProtocolICMP,
+		},
+	})
+}
+
+func TestSetPortRanges(t *testing.T) {
+	ctx := goopstest.NewContext(SetPortRanges)
+
+	stateIn := goopstest.State{
+		Ports: []goopstest.Port{
+			{
+				Port:     8000,
+				EndPort:  8100,
+				Protocol: "tcp",
+			},
+			{
+				Port:     9090,
+				Protocol: "tcp",
+			},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Ports) != 3 {
+		t.Fatalf("Expected 3 ports, got %d: %v", len(stateOut.Ports), stateOut.Ports)
+	}
+
+	if stateOut.Ports[0].Port != 8000 || stateOut.Ports[0].EndPort != 8200 {
+		t.Errorf("Expected port range 8000-8200, got %d-%d", stateOut.Ports[0].Port, stateOut.Ports[0].EndPort)
+	}
+
+	if stateOut.Ports[1].Port != 9090 || len(stateOut.Ports[1].Endpoints) != 1 || stateOut.Ports[1].Endpoints[0] != "metrics-endpoint" {
+		t.Errorf("Expected port 9090 opened for metrics-endpoint, got %d for %v", stateOut.Ports[1].Port, stateOut.Ports[1].Endpoints)
+	}
+
+	if stateOut.Ports[2].Protocol != "icmp" {
+		t.Errorf("Expected icmp, got %s", stateOut.Ports[2].Protocol)
+	}
+
+	if len(stateIn.Ports) != 2 || stateIn.Ports[0].EndPort != 8100 {
+		t.Errorf("Expected input state to be unchanged, got %v", stateIn.Ports)
+	}
+}
@@ -0,0 +1,261 @@
+package goopstest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+type DatabaseRequirerAppData struct {
+	Database string `json:"database" validate:"required"`
+}
+
+func WriteInvalidDatabaseRequest() error {
+	relationIDs, err := goops.GetRelationIDs("database")
+	if err != nil {
+		return err
+	}
+
+	// The error is deliberately ignored, goopstest must still fail the scenario.
+	_ = goops.SetAppRelationData(relationIDs[0], map[string]string{"database": ""})
+
+	return nil
+}
+
+func TestWriteInvalidRelationDataFailsScenario(t *testing.T) {
+	goops.RegisterInterfaceSchema("goopstest-database", goops.InterfaceSchema{
+		Requirer: goops.DataBagSchemas{
+			App: goops.StructSchema[DatabaseRequirerAppData](),
+		},
+	})
+	defer goops.UnregisterInterfaceSchema("goopstest-database")
+
+	ctx := goopstest.NewContext(WriteInvalidDatabaseRequest, goopstest.WithMetadata(goopstest.Metadata{
+		Name: "example",
+		Requires: map[string]goopstest.IntegrationMeta{
+			"database": {
+				Interface: "goopstest-database",
+			},
+		},
+	}))
+
+	stateIn := goopstest.State{
+		Leader: true,
+		Relations: []goopstest.Relation{
+			{
+				Endpoint:      "database",
+				RemoteAppName: "postgresql",
+			},
+		},
+	}
+
+	stateOut := ctx.Run("database-relation-changed", stateIn)
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(ctx.CharmErr, &validationErr) {
+		t.Fatalf("Expected the scenario to fail with a RelationDataValidationError, got %v", ctx.CharmErr)
+	}
+
+	if len(ctx.InvalidRelationData) != 1 {
+		t.Fatalf("Expected 1 invalid relation data entry, got %d", len(ctx.InvalidRelationData))
+	}
+
+	if len(stateOut.Relations[0].LocalAppData) != 0 {
+		t.Errorf("Expected invalid data not to be written, got %v", stateOut.Relations[0].LocalAppData)
+	}
+}
+
+func WriteDatabaseRequestUpdate() error {
+	return goops.SetAppRelationData("database:0", map[string]string{"extra-user-roles": "admin"})
+}
+
+func TestPartialRelationDataWriteIsMergedBeforeValidation(t *testing.T) {
+	goops.RegisterInterfaceSchema("goopstest-database", goops.InterfaceSchema{
+		Requirer: goops.DataBagSchemas{
+			App: goops.StructSchema[DatabaseRequirerAppData](),
+		},
+	})
+	defer goops.UnregisterInterfaceSchema("goopstest-database")
+
+	ctx := goopstest.NewContext(WriteDatabaseRequestUpdate, goopstest.WithMetadata(goopstest.Metadata{
+		Name: "example",
+		Requires: map[string]goopstest.IntegrationMeta{
+			"database": {
+				Interface: "goopstest-database",
+			},
+		},
+	}))
+
+	stateIn := goopstest.State{
+		Leader: true,
+		Relations: []goopstest.Relation{
+			{
+				Endpoint:      "database",
+				RemoteAppName: "postgresql",
+				LocalAppData: goopstest.DataBag{
+					"database": "mydb",
+				},
+			},
+		},
+	}
+
+	stateOut := ctx.Run("database-relation-changed", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Expected no error, got %v", ctx.CharmErr)
+	}
+
+	if stateOut.Relations[0].LocalAppData["extra-user-roles"] != "admin" {
+		t.Errorf("Expected extra-user-roles to be written, got %v", stateOut.Relations[0].LocalAppData)
+	}
+}
+
+func ReadEmptyDatabaseRequest() error {
+	request, err := goops.GetAppRelationDataAs[DatabaseRequirerAppData]("database:0", "postgresql/0")
+	if err != nil {
+		return err
+	}
+
+	if request.Database != "" {
+		return fmt.Errorf("expected no database, got %q", request.Database)
+	}
+
+	return nil
+}
+
+func TestReadEmptyRemoteRelationDataIsValid(t *testing.T) {
+	goops.RegisterInterfaceSchema("goopstest-database", goops.InterfaceSchema{
+		Requirer: goops.DataBagSchemas{
+			App: goops.StructSchema[DatabaseRequirerAppData](),
+		},
+	})
+	defer goops.UnregisterInterfaceSchema("goopstest-database")
+
+	ctx := goopstest.NewContext(ReadEmptyDatabaseRequest, goopstest.WithMetadata(goopstest.Metadata{
+		Name: "example",
+		Provides: map[string]goopstest.IntegrationMeta{
+			"database": {
+				Interface: "goopstest-database",
+			},
+		},
+	}))
+
+	stateIn := goopstest.State{
+		Relations: []goopstest.Relation{
+			{
+				Endpoint:      "database",
+				RemoteAppName: "postgresql",
+			},
+		},
+	}
+
+	_ = ctx.Run("database-relation-joined", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Expected no error, got %v", ctx.CharmErr)
+	}
+
+	if len(ctx.InvalidRelationData) != 0 {
+		t.Errorf("Expected no invalid relation data, got %v", ctx.InvalidRelationData)
+	}
+}
+
+func ReadInvalidDatabaseRequest() error {
+	_, err := goops.GetAppRelationData("database:0", "postgresql/0")
+	return err
+}
+
+func TestReadInvalidRelationDataInAction(t *testing.T) {
+	type databaseRequest struct {
+		Database string `json:"database" validate:"oneof=users orders"`
+	}
+
+	goops.RegisterInterfaceSchema("goopstest-orders", goops.InterfaceSchema{
+		Requirer: goops.DataBagSchemas{
+			App: goops.StructSchema[databaseRequest](),
+		},
+	})
+	defer goops.UnregisterInterfaceSchema("goopstest-orders")
+
+	ctx := goopstest.NewContext(ReadInvalidDatabaseRequest, goopstest.WithMetadata(goopstest.Metadata{
+		Name: "example",
+		Provides: map[string]goopstest.IntegrationMeta{
+			"database": {
+				Interface: "goopstest-orders",
+			},
+		},
+	}))
+
+	stateIn := goopstest.State{
+		Relations: []goopstest.Relation{
+			{
+				Endpoint:      "database",
+				ID:            "database:0",
+				RemoteAppName: "postgresql",
+				RemoteAppData: goopstest.DataBag{
+					"database": "payments",
+				},
+			},
+		},
+	}
+
+	_, err := ctx.RunAction("get-database", stateIn, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(ctx.CharmErr, &validationErr) {
+		t.Fatalf("Expected a RelationDataValidationError, got %v", ctx.CharmErr)
+	}
+}
+
+type ClusterPeerAppData struct {
+	Leader string `json:"leader" validate:"required"`
+}
+
+func WriteInvalidPeerData() error {
+	return goops.SetAppRelationData("cluster:0", map[string]string{"leader": ""})
+}
+
+func TestWriteInvalidPeerRelationData(t *testing.T) {
+	goops.RegisterInterfaceSchema("goopstest-cluster", goops.InterfaceSchema{
+		Peer: goops.DataBagSchemas{
+			App: goops.StructSchema[ClusterPeerAppData](),
+		},
+	})
+	defer goops.UnregisterInterfaceSchema("goopstest-cluster")
+
+	ctx := goopstest.NewContext(WriteInvalidPeerData, goopstest.WithMetadata(goopstest.Metadata{
+		Name: "example",
+		Peers: map[string]goopstest.IntegrationMeta{
+			"cluster": {
+				Interface: "goopstest-cluster",
+			},
+		},
+	}))
+
+	stateIn := goopstest.State{
+		Leader: true,
+		PeerRelations: []goopstest.PeerRelation{
+			{
+				Endpoint: "cluster",
+				ID:       "cluster:0",
+			},
+		},
+	}
+
+	_ = ctx.Run("cluster-relation-changed", stateIn)
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(ctx.CharmErr, &validationErr) {
+		t.Fatalf("Expected a RelationDataValidationError, got %v", ctx.CharmErr)
+	}
+
+	if validationErr.Role != goops.RolePeer {
+		t.Errorf("Expected role %q, got %q", goops.RolePeer, validationErr.Role)
+	}
+}
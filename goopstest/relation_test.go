@@ -3,6 +3,7 @@ package goopstest_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gruyaume/goops"
@@ -742,6 +743,112 @@ func TestCharmSetAppRelationData(t *testing.T) {
 	}
 }
 
+func SetLargeAppRelationData() error {
+	return goops.SetAppRelationData("certificates:0", map[string]string{
+		"chain": strings.Repeat("a", 100*1024),
+	})
+}
+
+func TestCharmSetLargeAppRelationData(t *testing.T) {
+	ctx := goopstest.NewContext(SetLargeAppRelationData)
+
+	stateIn := goopstest.State{
+		Leader: true,
+		Relations: []goopstest.Relation{
+			{
+				Endpoint: "certificates",
+			},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no CharmErr, got %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Relations[0].LocalAppData["chain"]) != 100*1024 {
+		t.Fatalf("expected chain of %d bytes, got %d bytes", 100*1024, len(stateOut.Relations[0].LocalAppData["chain"]))
+	}
+}
+
+type certificateRequest struct {
+	CSR      string `json:"certificate_signing_requests"`
+	Comments string `json:"comments,omitempty"`
+}
+
+func ClearAppRelationDataComments() error {
+	return goops.SetAppRelationDataFrom("certificates:0", certificateRequest{CSR: "new-csr"})
+}
+
+func TestCharmSetAppRelationDataFromClearsOmitEmptyKey(t *testing.T) {
+	ctx := goopstest.NewContext(ClearAppRelationDataComments)
+
+	stateIn := goopstest.State{
+		Leader: true,
+		Relations: []goopstest.Relation{
+			{
+				Endpoint: "certificates",
+				LocalAppData: goopstest.DataBag{
+					"certificate_signing_requests": "old-csr",
+					"comments":                     "please renew",
+				},
+			},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no CharmErr, got %v", ctx.CharmErr)
+	}
+
+	appData := stateOut.Relations[0].LocalAppData
+
+	if appData["certificate_signing_requests"] != "new-csr" {
+		t.Errorf("expected 'new-csr', got '%s'", appData["certificate_signing_requests"])
+	}
+
+	if _, ok := appData["comments"]; ok {
+		t.Errorf("expected comments to be deleted, got '%s'", appData["comments"])
+	}
+}
+
+func ReadRemoteCertificateRequest(client *goops.Client) error {
+	request, err := goops.GetAppRelationDataAsWithClient[certificateRequest](client, "certificates:0", "tls-provider/0")
+	if err != nil {
+		return err
+	}
+
+	if request.CSR != "csr-data" {
+		return fmt.Errorf("expected 'csr-data', got '%s'", request.CSR)
+	}
+
+	return nil
+}
+
+func TestClientCharmGetAppRelationDataAs(t *testing.T) {
+	ctx := goopstest.NewClientContext(ReadRemoteCertificateRequest)
+
+	stateIn := goopstest.State{
+		Relations: []goopstest.Relation{
+			{
+				Endpoint:      "certificates",
+				RemoteAppName: "tls-provider",
+				RemoteAppData: goopstest.DataBag{
+					"certificate_signing_requests": "csr-data",
+				},
+			},
+		},
+	}
+
+	_ = ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no CharmErr, got %v", ctx.CharmErr)
+	}
+}
+
 func TestCharmSetAppRelationDataNoRelation(t *testing.T) {
 	ctx := goopstest.NewContext(SetAppRelationData)
 
@@ -59,3 +59,86 @@ func TestResourceGetDoesntExist(t *testing.T) {
 		t.Errorf("Expected error %q, got %q", expectedErr, ctx.CharmErr.Error())
 	}
 }
+
+func VerifySoftwareResource() error {
+	resource, err := goops.GetResourceInfo("software")
+	if err != nil {
+		return err
+	}
+
+	err = resource.VerifySHA384("55fb972381d098d7931503fc9fe80009eb5952111f1086fbcfedb109aa5b0a2cb314f714f0ff7d347e356fb8b828b5a4")
+	if err != nil {
+		return err
+	}
+
+	size, err := resource.Size()
+	if err != nil {
+		return err
+	}
+
+	return goops.SetUnitStatus(goops.StatusActive, fmt.Sprintf("%s resource of %d bytes", resource.Type, size))
+}
+
+func TestResourceFileFromTestdata(t *testing.T) {
+	ctx := goopstest.NewContext(VerifySoftwareResource)
+
+	stateIn := goopstest.State{
+		Resources: []goopstest.Resource{
+			{Name: "software", Path: "testdata/software.txt"},
+		},
+	}
+
+	stateOut := ctx.Run("install", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if stateOut.UnitStatus.Message != "file resource of 12 bytes" {
+		t.Errorf("expected status message %q, got %q", "file resource of 12 bytes", stateOut.UnitStatus.Message)
+	}
+}
+
+func GetImageResource() error {
+	resource, err := goops.GetResourceInfo("workload-image")
+	if err != nil {
+		return err
+	}
+
+	if resource.Image == nil {
+		return fmt.Errorf("expected image details for resource of type %s", resource.Type)
+	}
+
+	goops.LogInfof("pulling %s with password %s", resource.Image.RegistryPath, resource.Image.Password)
+
+	return nil
+}
+
+func TestResourceOCIImage(t *testing.T) {
+	ctx := goopstest.NewContext(GetImageResource, goopstest.WithMetadata(
+		goopstest.Metadata{
+			Resources: map[string]goopstest.ResourceMeta{
+				"workload-image": {
+					Type: "oci-image",
+				},
+			},
+		},
+	))
+
+	stateIn := goopstest.State{
+		Resources: []goopstest.Resource{
+			{Name: "workload-image", Path: "testdata/oci-image.yaml"},
+		},
+	}
+
+	_ = ctx.Run("install", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	expectedMessage := "pulling ghcr.io/example/workload:1.0 with password <redacted>"
+	if len(ctx.JujuLog) != 1 || ctx.JujuLog[0].Message != expectedMessage {
+		t.Errorf("expected log message %q, got %v", expectedMessage, ctx.JujuLog)
+	}
+}
@@ -1,6 +1,7 @@
 package goopstest_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -519,8 +520,12 @@ func TestCharmGetSecretInfoByIDNonLeader(t *testing.T) {
 		t.Fatalf("expected an error when not leader, got nil")
 	}
 
-	if ctx.CharmErr.Error() != `failed to get secret info: ERROR secret "12345" not found` {
-		t.Errorf("got CharmErr=%q, want 'failed to get secret info: ERROR secret \"12345\" not found'", ctx.CharmErr.Error())
+	if ctx.CharmErr.Error() != `failed to get secret info: command secret-info-get failed: ERROR secret "12345" not found` {
+		t.Errorf("got CharmErr=%q, want 'failed to get secret info: command secret-info-get failed: ERROR secret \"12345\" not found'", ctx.CharmErr.Error())
+	}
+
+	if !errors.Is(ctx.CharmErr, goops.ErrSecretNotFound) {
+		t.Errorf("expected CharmErr to match goops.ErrSecretNotFound, got %v", ctx.CharmErr)
 	}
 }
 
@@ -618,8 +623,8 @@ func TestCharmGetSecretInfoByLabelNonLeader(t *testing.T) {
 		t.Fatalf("expected an error when not leader, got nil")
 	}
 
-	if ctx.CharmErr.Error() != `failed to get secret info: ERROR secret "whatever-label" not found` {
-		t.Errorf("got CharmErr=%q, want 'failed to get secret info: ERROR secret \"whatever-label\" not found'", ctx.CharmErr.Error())
+	if ctx.CharmErr.Error() != `failed to get secret info: command secret-info-get failed: ERROR secret "whatever-label" not found` {
+		t.Errorf("got CharmErr=%q, want 'failed to get secret info: command secret-info-get failed: ERROR secret \"whatever-label\" not found'", ctx.CharmErr.Error())
 	}
 }
 
@@ -843,8 +848,8 @@ func TestCharmGrantSecretNonLeader(t *testing.T) {
 		t.Fatalf("expected an error when not leader, got nil")
 	}
 
-	if ctx.CharmErr.Error() != "failed to grant secret: ERROR secret \"12345\" not found" {
-		t.Errorf("got CharmErr=%q, want 'failed to grant secret: ERROR secret \"12345\" not found'", ctx.CharmErr.Error())
+	if ctx.CharmErr.Error() != "failed to grant secret: command secret-grant failed: ERROR secret \"12345\" not found" {
+		t.Errorf("got CharmErr=%q, want 'failed to grant secret: command secret-grant failed: ERROR secret \"12345\" not found'", ctx.CharmErr.Error())
 	}
 }
 
@@ -1005,3 +1010,69 @@ func TestCharmRevokeSecret(t *testing.T) {
 		t.Fatalf("expected 1 secret, got %d", len(stateOut.Secrets))
 	}
 }
+
+func LogSecretValue() error {
+	secret, err := goops.GetSecretByLabel("database-credentials", false, true)
+	if err != nil {
+		return err
+	}
+
+	goops.LogInfof("connecting with password %s", secret["password"])
+
+	return nil
+}
+
+func TestCharmLogSecretValueIsRedacted(t *testing.T) {
+	ctx := goopstest.NewContext(LogSecretValue)
+
+	stateIn := goopstest.State{
+		Secrets: []goopstest.Secret{
+			{
+				Label:   "database-credentials",
+				Content: map[string]string{"password": "hunter2"},
+			},
+		},
+	}
+
+	_ = ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if len(ctx.JujuLog) != 1 {
+		t.Fatalf("expected 1 log line, got %d", len(ctx.JujuLog))
+	}
+
+	expectedMessage := "connecting with password <redacted>"
+	if ctx.JujuLog[0].Message != expectedMessage {
+		t.Errorf("expected log message %q, got %q", expectedMessage, ctx.JujuLog[0].Message)
+	}
+}
+
+func AddBinarySecret() error {
+	_, err := goops.AddSecret(&goops.AddSecretOptions{
+		Label:         "keystore",
+		BinaryContent: map[string][]byte{"keystore": {0x00, 0xff, 0x10}},
+	})
+
+	return err
+}
+
+func TestCharmAddBinarySecret(t *testing.T) {
+	ctx := goopstest.NewContext(AddBinarySecret)
+
+	stateOut := ctx.Run("start", goopstest.State{Leader: true})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Secrets) != 1 {
+		t.Fatalf("expected 1 secret, got %d", len(stateOut.Secrets))
+	}
+
+	if stateOut.Secrets[0].Content["keystore"] != string([]byte{0x00, 0xff, 0x10}) {
+		t.Errorf("expected decoded keystore content, got %q", stateOut.Secrets[0].Content["keystore"])
+	}
+}
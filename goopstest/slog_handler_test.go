@@ -0,0 +1,59 @@
+package goopstest_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func SlogCharm() error {
+	logger := slog.New(goops.NewSlogHandler(nil))
+
+	logger.Warn("certificate expires soon", "days", 7)
+
+	return nil
+}
+
+func TestSlogHandler(t *testing.T) {
+	ctx := goopstest.NewContext(SlogCharm)
+
+	_ = ctx.Run("update-status", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if len(ctx.JujuLog) != 1 {
+		t.Fatalf("expected 1 log line, got %d", len(ctx.JujuLog))
+	}
+
+	logLine := ctx.JujuLog[0]
+
+	if logLine.Level != goopstest.LogLevelWarning {
+		t.Errorf("expected level %q, got %q", goopstest.LogLevelWarning, logLine.Level)
+	}
+
+	if logLine.Message != "certificate expires soon days=7" {
+		t.Errorf("expected message %q, got %q", "certificate expires soon days=7", logLine.Message)
+	}
+
+	if len(ctx.JujuLogRecords) != 1 {
+		t.Fatalf("expected 1 log record, got %d", len(ctx.JujuLogRecords))
+	}
+
+	record := ctx.JujuLogRecords[0]
+
+	if record.Level != goops.Warning {
+		t.Errorf("expected record level %d, got %d", goops.Warning, record.Level)
+	}
+
+	if record.Message != "certificate expires soon" {
+		t.Errorf("expected record message %q, got %q", "certificate expires soon", record.Message)
+	}
+
+	if record.Attrs["days"] != "7" {
+		t.Errorf("expected attribute days to be %q, got %q", "7", record.Attrs["days"])
+	}
+}
@@ -4,16 +4,18 @@ import (
 	"time"
 
 	"github.com/canonical/pebble/client"
+	"github.com/gruyaume/goops"
 )
 
 type Secret struct {
 	ID          string
 	Label       string
 	Content     map[string]string
-	Owner       string
+	Owner       string // "unit", "application" or "user" for secrets created with juju add-secret
 	Description string
 	Rotate      string
 	Expire      time.Time
+	Granted     bool // Whether a user-owned secret was granted to the application
 }
 
 type DataBag map[string]string
@@ -41,9 +43,41 @@ type PeerRelation struct {
 	PeersData     map[UnitID]DataBag // Does not include data for the unit under test
 }
 
+// Port is a port, or a range of ports from Port to EndPort, opened by the unit.
+// EndPort is 0 for a single port, and both are 0 for icmp.
+// Endpoints lists the relation endpoints the port is opened for, all of them when empty.
 type Port struct {
-	Port     int
-	Protocol string
+	Port      int
+	EndPort   int
+	Protocol  string
+	Endpoints []string
+}
+
+// Network is the network configuration returned by network-get for a binding.
+// When RelationID is set, it is only returned for that relation, with -r. Otherwise
+// it is returned for the binding and for relations without their own configuration.
+type Network struct {
+	Binding          string
+	RelationID       string
+	BindAddresses    []goops.BindAddress
+	IngressAddresses []string
+	EgressSubnets    []string
+}
+
+// Storage is a storage instance attached to the unit.
+// ID is the storage instance ID, for example "data/0", and Kind is block or filesystem.
+type Storage struct {
+	ID       string
+	Kind     string
+	Location string
+}
+
+// Resource is a resource provided to the charm. Path is a local file, typically
+// under testdata, whose content the charm reads as the resource. For oci-image
+// resources, declared as such in the metadata, the file holds the image details.
+type Resource struct {
+	Name string
+	Path string
 }
 
 type Model struct {
@@ -109,6 +143,11 @@ type State struct {
 	Relations          []Relation
 	PeerRelations      []PeerRelation
 	Ports              []Port
+	Networks           []Network
+	Storages           []Storage
+	Resources          []Resource
+	Trusted            bool
+	Credential         *goops.CloudSpec
 	Model              Model
 	StoredState        StoredState
 	Containers         []Container
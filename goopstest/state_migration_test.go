@@ -0,0 +1,155 @@
+package goopstest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+var charmMigrations = []goops.Migration{
+	{
+		Version:     1,
+		Description: "rename ca-cert to ca-certificate",
+		Migrate: func(client *goops.Client) error {
+			value, err := client.GetState("ca-cert")
+			if err != nil {
+				return err
+			}
+
+			err = client.SetState("ca-certificate", value)
+			if err != nil {
+				return err
+			}
+
+			return client.DeleteState("ca-cert")
+		},
+	},
+	{
+		Version:     2,
+		Description: "move ca-certificate to the tls namespace",
+		Migrate: func(client *goops.Client) error {
+			value, err := client.GetState("ca-certificate")
+			if err != nil {
+				return err
+			}
+
+			err = client.NewStoredState("tls").Set("ca-certificate", value)
+			if err != nil {
+				return err
+			}
+
+			return client.DeleteState("ca-certificate")
+		},
+	},
+}
+
+func MigratingCharm() error {
+	dispatcher := goops.NewDispatcher()
+	dispatcher.Migrations(charmMigrations...)
+	dispatcher.Reconcile(func(goops.Event) error {
+		return nil
+	})
+
+	return dispatcher.Run()
+}
+
+func TestMigrateStateFromOldVersion(t *testing.T) {
+	ctx := goopstest.NewContext(MigratingCharm)
+
+	stateIn := goopstest.State{
+		StoredState: goopstest.StoredState{
+			goops.StateSchemaVersionKey: "1",
+			"ca-certificate":            "example-ca",
+		},
+	}
+
+	stateOut := ctx.Run("upgrade-charm", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	expected := goopstest.StoredState{
+		goops.StateSchemaVersionKey: "2",
+		"tls.ca-certificate":        `"example-ca"`,
+	}
+
+	if len(stateOut.StoredState) != len(expected) {
+		t.Fatalf("got StoredState=%v, want %v", stateOut.StoredState, expected)
+	}
+
+	for key, value := range expected {
+		if stateOut.StoredState[key] != value {
+			t.Errorf("got StoredState[%s]=%q, want %q", key, stateOut.StoredState[key], value)
+		}
+	}
+}
+
+func TestMigrateStateOnInstallRecordsLatestVersion(t *testing.T) {
+	ctx := goopstest.NewContext(MigratingCharm)
+
+	stateOut := ctx.Run("install", goopstest.State{})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if stateOut.StoredState[goops.StateSchemaVersionKey] != "2" {
+		t.Errorf("got schema version %q, want 2", stateOut.StoredState[goops.StateSchemaVersionKey])
+	}
+}
+
+func TestMigrateStateOnFirstHookAfterUpgrade(t *testing.T) {
+	ctx := goopstest.NewContext(MigratingCharm)
+
+	stateIn := goopstest.State{
+		StoredState: goopstest.StoredState{
+			"ca-cert": "example-ca",
+		},
+	}
+
+	stateOut := ctx.Run("config-changed", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+
+	if stateOut.StoredState[goops.StateSchemaVersionKey] != "2" {
+		t.Errorf("got schema version %q, want 2", stateOut.StoredState[goops.StateSchemaVersionKey])
+	}
+
+	if stateOut.StoredState["tls.ca-certificate"] != `"example-ca"` {
+		t.Errorf("got StoredState=%v, want migrated ca certificate", stateOut.StoredState)
+	}
+}
+
+func PanickingMigrationCharm() error {
+	dispatcher := goops.NewDispatcher()
+	dispatcher.Migrations(goops.Migration{
+		Version: 1,
+		Migrate: func(*goops.Client) error {
+			panic("unexpected")
+		},
+	})
+	dispatcher.Reconcile(func(goops.Event) error {
+		return nil
+	})
+
+	return dispatcher.Run()
+}
+
+func TestMigrateStatePanicIsRecovered(t *testing.T) {
+	ctx := goopstest.NewContext(PanickingMigrationCharm)
+
+	_ = ctx.Run("upgrade-charm", goopstest.State{})
+
+	if ctx.CharmErr == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if !strings.Contains(ctx.CharmErr.Error(), "panic while handling upgrade-charm: unexpected") {
+		t.Errorf("unexpected error message: %v", ctx.CharmErr)
+	}
+}
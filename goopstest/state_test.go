@@ -178,3 +178,146 @@ func TestDeleteStateNoKey(t *testing.T) {
 		t.Errorf("expected StoredState to be empty, got %d items", len(stateOut.StoredState))
 	}
 }
+
+type CertificatesState struct {
+	Issued int `json:"issued"`
+}
+
+func IncrementIssuedCertificates() error {
+	stored := goops.NewStoredState("certificates")
+
+	var state CertificatesState
+
+	_, err := stored.Get("state", &state)
+	if err != nil {
+		return err
+	}
+
+	state.Issued++
+
+	return stored.Set("state", state)
+}
+
+func TestStoredStateNamespace(t *testing.T) {
+	ctx := goopstest.NewContext(IncrementIssuedCertificates)
+
+	stateIn := goopstest.State{
+		StoredState: goopstest.StoredState{
+			"certificates.state": `{"issued":1}`,
+			"state":              "unrelated",
+		},
+	}
+
+	stateOut := ctx.Run("update-status", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if stateOut.StoredState["certificates.state"] != `{"issued":2}` {
+		t.Errorf("got certificates.state=%q, want %q", stateOut.StoredState["certificates.state"], `{"issued":2}`)
+	}
+
+	if stateOut.StoredState["state"] != "unrelated" {
+		t.Errorf("Expected keys outside the namespace to be untouched, got %q", stateOut.StoredState["state"])
+	}
+}
+
+func ListStoredStateKeys() error {
+	keys, err := goops.NewStoredState("certificates").Keys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		return fmt.Errorf("expected keys [a b], got %v", keys)
+	}
+
+	state, err := goops.ListState()
+	if err != nil {
+		return err
+	}
+
+	if len(state) != 3 {
+		return fmt.Errorf("expected 3 state keys, got %d", len(state))
+	}
+
+	return nil
+}
+
+func TestListStoredStateKeys(t *testing.T) {
+	ctx := goopstest.NewContext(ListStoredStateKeys)
+
+	stateIn := goopstest.State{
+		StoredState: goopstest.StoredState{
+			"certificates.a": "1",
+			"certificates.b": "2",
+			"other.c":        "3",
+		},
+	}
+
+	_ = ctx.Run("update-status", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
+
+func WriteNestedStoredState() error {
+	err := goops.NewStoredState("tls").Set("x", "parent")
+	if err != nil {
+		return err
+	}
+
+	err = goops.NewStoredState("tls.ca").Set("x", "child")
+	if err != nil {
+		return err
+	}
+
+	keys, err := goops.NewStoredState("tls").Keys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) != 2 || keys[0] != "ca.y" || keys[1] != "x" {
+		return fmt.Errorf("expected keys [ca.y x], got %v", keys)
+	}
+
+	var value string
+
+	_, err = goops.NewStoredState("tls").Get("x", &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "parent" {
+		return fmt.Errorf("expected %q, got %q", "parent", value)
+	}
+
+	keys, err = goops.NewStoredState("tls.ca").Keys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) != 1 || keys[0] != "x" {
+		return fmt.Errorf("expected keys [x], got %v", keys)
+	}
+
+	return nil
+}
+
+func TestNestedStoredStateNamespaces(t *testing.T) {
+	ctx := goopstest.NewContext(WriteNestedStoredState)
+
+	stateIn := goopstest.State{
+		StoredState: goopstest.StoredState{
+			"tls.ca.y": `"key of the tls namespace"`,
+		},
+	}
+
+	_ = ctx.Run("update-status", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+}
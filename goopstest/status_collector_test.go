@@ -0,0 +1,81 @@
+package goopstest_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func CollectStatuses() error {
+	goops.AddUnitStatus(goops.StatusActive)
+	goops.AddUnitStatus(goops.StatusWaiting, "waiting for certificates")
+	goops.AddAppStatus(goops.StatusActive)
+	goops.AddAppStatus(goops.StatusBlocked, "invalid config")
+
+	return goops.CommitStatus()
+}
+
+func TestCollectStatusLeader(t *testing.T) {
+	ctx := goopstest.NewContext(CollectStatuses)
+
+	stateIn := goopstest.State{
+		Leader: true,
+	}
+
+	stateOut := ctx.Run("update-status", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	expectedUnitStatus := goopstest.Status{
+		Name:    goopstest.StatusWaiting,
+		Message: "waiting for certificates",
+	}
+	if stateOut.UnitStatus != expectedUnitStatus {
+		t.Errorf("got UnitStatus=%v, want %v", stateOut.UnitStatus, expectedUnitStatus)
+	}
+
+	expectedAppStatus := goopstest.Status{
+		Name:    goopstest.StatusBlocked,
+		Message: "invalid config",
+	}
+	if stateOut.AppStatus != expectedAppStatus {
+		t.Errorf("got AppStatus=%v, want %v", stateOut.AppStatus, expectedAppStatus)
+	}
+
+	if len(ctx.UnitStatusCandidates) != 2 {
+		t.Fatalf("Expected 2 unit status candidates, got %d", len(ctx.UnitStatusCandidates))
+	}
+
+	if ctx.UnitStatusCandidates[0].Name != goopstest.StatusActive {
+		t.Errorf("Expected first candidate to be active, got %v", ctx.UnitStatusCandidates[0])
+	}
+
+	if len(ctx.AppStatusCandidates) != 2 {
+		t.Fatalf("Expected 2 app status candidates, got %d", len(ctx.AppStatusCandidates))
+	}
+}
+
+func TestCollectStatusNonLeader(t *testing.T) {
+	ctx := goopstest.NewContext(CollectStatuses)
+
+	stateIn := goopstest.State{
+		Leader: false,
+	}
+
+	stateOut := ctx.Run("update-status", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if stateOut.UnitStatus.Name != goopstest.StatusWaiting {
+		t.Errorf("got UnitStatus=%v, want %v", stateOut.UnitStatus.Name, goopstest.StatusWaiting)
+	}
+
+	if stateOut.AppStatus.Name != "" {
+		t.Errorf("Expected app status not to be set on a non-leader, got %v", stateOut.AppStatus)
+	}
+}
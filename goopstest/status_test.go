@@ -1,6 +1,7 @@
 package goopstest_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -403,3 +404,22 @@ func TestSetAppStatusNonLeader(t *testing.T) {
 		t.Errorf("got CharmErr=%q, want %q", ctx.CharmErr.Error(), expectedErr)
 	}
 }
+
+func GetAppStatusAsNonLeader() error {
+	_, err := goops.GetAppStatus()
+	if errors.Is(err, goops.ErrNotLeader) {
+		return nil
+	}
+
+	return fmt.Errorf("expected goops.ErrNotLeader, got %v", err)
+}
+
+func TestGetAppStatusNotLeaderError(t *testing.T) {
+	ctx := goopstest.NewContext(GetAppStatusAsNonLeader)
+
+	_ = ctx.Run("start", goopstest.State{Leader: false})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("expected no error, got: %v", ctx.CharmErr)
+	}
+}
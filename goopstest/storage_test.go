@@ -0,0 +1,104 @@
+package goopstest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruyaume/goops"
+	"github.com/gruyaume/goops/goopstest"
+)
+
+func StorageAttached() error {
+	storage, err := goops.GetCurrentStorage()
+	if err != nil {
+		return fmt.Errorf("could not get storage: %w", err)
+	}
+
+	path, err := storage.FilesystemPath()
+	if err != nil {
+		return err
+	}
+
+	return goops.SetUnitStatus(goops.StatusActive, "storage "+storage.Name()+" mounted at "+path)
+}
+
+func TestCharmStorageAttached(t *testing.T) {
+	ctx := goopstest.NewContext(StorageAttached)
+
+	stateIn := goopstest.State{
+		Storages: []goopstest.Storage{
+			{ID: "data/0", Kind: "filesystem", Location: "/srv/data"},
+		},
+	}
+
+	stateOut := ctx.RunWithEvent("data-storage-attached", stateIn, goopstest.Event{StorageID: "data/0"})
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	expectedMessage := "storage data mounted at /srv/data"
+	if stateOut.UnitStatus.Message != expectedMessage {
+		t.Errorf("expected status message %q, got %q", expectedMessage, stateOut.UnitStatus.Message)
+	}
+}
+
+func TestCharmStorageAttachedUnknownStorage(t *testing.T) {
+	ctx := goopstest.NewContext(StorageAttached)
+
+	_ = ctx.RunWithEvent("data-storage-attached", goopstest.State{}, goopstest.Event{StorageID: "data/0"})
+
+	expected := `could not get storage: failed to get storage: command storage-get failed: ERROR invalid value "data/0" for option -s: storage instance "data/0" not found`
+	if ctx.CharmErr == nil || ctx.CharmErr.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, ctx.CharmErr)
+	}
+}
+
+func AddAndListStorage() error {
+	err := goops.AddStorage("data", 2)
+	if err != nil {
+		return err
+	}
+
+	ids, err := goops.ListStorage("data")
+	if err != nil {
+		return err
+	}
+
+	return goops.SetUnitStatus(goops.StatusActive, fmt.Sprintf("%d data storage instances", len(ids)))
+}
+
+func TestCharmAddStorage(t *testing.T) {
+	ctx := goopstest.NewContext(
+		AddAndListStorage,
+		goopstest.WithMetadata(goopstest.Metadata{
+			Storage: map[string]goopstest.StorageMeta{
+				"data": {Type: "filesystem", Location: "/srv/data"},
+			},
+		}),
+	)
+
+	stateIn := goopstest.State{
+		Storages: []goopstest.Storage{
+			{ID: "data/0", Kind: "filesystem", Location: "/srv/data"},
+		},
+	}
+
+	stateOut := ctx.Run("start", stateIn)
+
+	if ctx.CharmErr != nil {
+		t.Fatalf("Charm returned an error: %v", ctx.CharmErr)
+	}
+
+	if len(stateOut.Storages) != 3 {
+		t.Fatalf("expected 3 storages, got %d", len(stateOut.Storages))
+	}
+
+	if stateOut.Storages[2].ID != "data/2" {
+		t.Errorf("expected storage ID %q, got %q", "data/2", stateOut.Storages[2].ID)
+	}
+
+	if stateOut.UnitStatus.Message != "3 data storage instances" {
+		t.Errorf("expected status message %q, got %q", "3 data storage instances", stateOut.UnitStatus.Message)
+	}
+}
@@ -2,7 +2,6 @@ package tls_certificates
 
 import (
 	"crypto/x509"
-	"encoding/json"
 	"encoding/pem"
 	"fmt"
 
@@ -21,8 +20,23 @@ type CertificateSigningRequestProviderAppRelationData struct {
 	Certificate               string   `json:"certificate"`
 }
 
+type RequirerUnitRelationData struct {
+	CertificateSigningRequests []CertificateSigningRequestRequirerRelationData `json:"certificate_signing_requests,omitempty"`
+}
+
 type ProviderAppRelationData struct {
-	Certificates string `json:"certificates"`
+	Certificates []CertificateSigningRequestProviderAppRelationData `json:"certificates"`
+}
+
+func init() {
+	goops.RegisterInterfaceSchema("tls-certificates", goops.InterfaceSchema{
+		Provider: goops.DataBagSchemas{
+			App: goops.StructSchema[ProviderAppRelationData](),
+		},
+		Requirer: goops.DataBagSchemas{
+			Unit: goops.StructSchema[RequirerUnitRelationData](),
+		},
+	})
 }
 
 type CertificateSigningRequest struct {
@@ -90,24 +104,12 @@ func GetOutstandingCertificateRequests(relationName string) ([]RequirerCertifica
 		}
 
 		for _, unitID := range relationUnits {
-			relationData, err := goops.GetUnitRelationData(relationID, unitID)
+			relationData, err := goops.GetUnitRelationDataAs[RequirerUnitRelationData](relationID, unitID)
 			if err != nil {
 				return nil, fmt.Errorf("could not get relation data: %w", err)
 			}
 
-			csrJSON, ok := relationData["certificate_signing_requests"]
-			if !ok {
-				continue
-			}
-
-			var certificateSigningRequestsRelationData []CertificateSigningRequestRequirerRelationData
-
-			err = json.Unmarshal([]byte(csrJSON), &certificateSigningRequestsRelationData)
-			if err != nil {
-				return nil, fmt.Errorf("could not unmarshal certificate signing requests: %w", err)
-			}
-
-			for _, csrRelationData := range certificateSigningRequestsRelationData {
+			for _, csrRelationData := range relationData.CertificateSigningRequests {
 				csrString := csrRelationData.CertificateSigningRequest
 
 				csr, err := loadCertificateSigningRequest(csrString)
@@ -129,28 +131,21 @@ func GetOutstandingCertificateRequests(relationName string) ([]RequirerCertifica
 }
 
 func SetRelationCertificate(relationID string, providerCertificate ProviderCertificate) error {
-	appData := []CertificateSigningRequestProviderAppRelationData{
-		{
-			CA:                        providerCertificate.CA.Raw,
-			Chain:                     []string{},
-			CertificateSigningRequest: providerCertificate.CertificateSigningRequest.Raw,
-			Certificate:               providerCertificate.Certificate.Raw,
+	appData := ProviderAppRelationData{
+		Certificates: []CertificateSigningRequestProviderAppRelationData{
+			{
+				CA:                        providerCertificate.CA.Raw,
+				Chain:                     []string{},
+				CertificateSigningRequest: providerCertificate.CertificateSigningRequest.Raw,
+				Certificate:               providerCertificate.Certificate.Raw,
+			},
 		},
 	}
 	for _, cert := range providerCertificate.Chain {
-		appData[0].Chain = append(appData[0].Chain, cert.Raw)
-	}
-
-	appDataJSON, err := json.Marshal(appData)
-	if err != nil {
-		return fmt.Errorf("could not marshal app data: %w", err)
-	}
-
-	relationData := map[string]string{
-		"certificates": string(appDataJSON),
+		appData.Certificates[0].Chain = append(appData.Certificates[0].Chain, cert.Raw)
 	}
 
-	err = goops.SetAppRelationData(relationID, relationData)
+	err := goops.SetAppRelationDataFrom(relationID, appData)
 	if err != nil {
 		return fmt.Errorf("could not set relation data: %w", err)
 	}
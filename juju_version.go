@@ -0,0 +1,207 @@
+package goops
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// JujuVersion is a Juju version, for example 3.6.1, 3.6-beta2 or 3.6.1.2.
+// Tag is set for pre-releases, in which case Patch holds the pre-release number.
+type JujuVersion struct {
+	Major int
+	Minor int
+	Tag   string
+	Patch int
+	Build int
+}
+
+var jujuVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.|-([a-z]+))(\d+)(?:\.(\d+))?$`)
+
+// ParseJujuVersion parses a Juju version, as found in JUJU_VERSION.
+func ParseJujuVersion(s string) (JujuVersion, error) {
+	matches := jujuVersionPattern.FindStringSubmatch(s)
+	if matches == nil {
+		return JujuVersion{}, fmt.Errorf("invalid Juju version %q", s)
+	}
+
+	version := JujuVersion{Tag: matches[3]}
+
+	numbers := []*int{&version.Major, &version.Minor, nil, &version.Patch, &version.Build}
+
+	for i, number := range numbers {
+		if number == nil || matches[i+1] == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return JujuVersion{}, fmt.Errorf("invalid Juju version %q: %w", s, err)
+		}
+
+		*number = value
+	}
+
+	return version, nil
+}
+
+func (v JujuVersion) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%d.%d", v.Major, v.Minor)
+
+	if v.Tag != "" {
+		fmt.Fprintf(&builder, "-%s%d", v.Tag, v.Patch)
+	} else {
+		fmt.Fprintf(&builder, ".%d", v.Patch)
+	}
+
+	if v.Build != 0 {
+		fmt.Fprintf(&builder, ".%d", v.Build)
+	}
+
+	return builder.String()
+}
+
+// Compare returns -1, 0 or 1 when v is older than, equal to or newer than other.
+// Pre-releases are older than the release of the same minor version.
+func (v JujuVersion) Compare(other JujuVersion) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	if v.Tag != other.Tag {
+		switch {
+		case v.Tag == "":
+			return 1
+		case other.Tag == "":
+			return -1
+		default:
+			return cmp.Compare(v.Tag, other.Tag)
+		}
+	}
+
+	if c := cmp.Compare(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(v.Build, other.Build)
+}
+
+// AtLeast reports whether v is the same as or newer than other.
+func (v JujuVersion) AtLeast(other JujuVersion) bool {
+	return v.Compare(other) >= 0
+}
+
+// Feature is a Juju capability that is not available in every supported Juju version.
+type Feature string
+
+const (
+	FeatureSecrets           Feature = "secrets"
+	FeatureSecretConfig      Feature = "secret-config"
+	FeatureOpenPortEndpoints Feature = "open-port-endpoints"
+	FeaturePebbleNotices     Feature = "pebble-notices"
+)
+
+// featureVersions maps features to the first Juju version supporting them.
+var featureVersions = map[Feature]JujuVersion{
+	FeatureSecrets:           {Major: 3, Minor: 0, Patch: 3},
+	FeatureSecretConfig:      {Major: 3, Minor: 4},
+	FeatureOpenPortEndpoints: {Major: 3, Minor: 0},
+	FeaturePebbleNotices:     {Major: 3, Minor: 4},
+}
+
+// Supports reports whether the version supports the feature.
+func (v JujuVersion) Supports(feature Feature) bool {
+	minimum, ok := featureVersions[feature]
+	if !ok {
+		return false
+	}
+
+	return v.AtLeast(minimum)
+}
+
+// MinimumJujuVersion returns the first Juju version supporting the feature.
+func MinimumJujuVersion(feature Feature) (JujuVersion, bool) {
+	version, ok := featureVersions[feature]
+
+	return version, ok
+}
+
+// RequiredFeature returns the feature a hook tool call needs, if any.
+// It is meant for CommandRunner implementations that simulate older Juju versions.
+func RequiredFeature(tool string, args ...string) (Feature, bool) {
+	switch {
+	case strings.HasPrefix(tool, "secret-"):
+		return FeatureSecrets, true
+	case tool == openPortCommand || tool == closePortCommand || tool == openedPortsCommand:
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--endpoints") {
+				return FeatureOpenPortEndpoints, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// GetJujuVersion returns the version of the Juju agent running the hook, from JUJU_VERSION.
+func (c *Client) GetJujuVersion() (JujuVersion, error) {
+	return ParseJujuVersion(c.envGetter.Get("JUJU_VERSION"))
+}
+
+// GetJujuVersion returns the version of the Juju agent running the hook, from JUJU_VERSION.
+func GetJujuVersion() (JujuVersion, error) {
+	return defaultClient.GetJujuVersion()
+}
+
+// Supports reports whether the Juju agent running the hook supports the feature.
+// Features are assumed to be supported when JUJU_VERSION is not set or cannot be parsed.
+func (c *Client) Supports(feature Feature) bool {
+	return c.requireFeature(feature) == nil
+}
+
+// Supports reports whether the Juju agent running the hook supports the feature.
+// Features are assumed to be supported when JUJU_VERSION is not set or cannot be parsed.
+func Supports(feature Feature) bool {
+	return defaultClient.Supports(feature)
+}
+
+// requireFeature returns an error wrapping ErrUnsupported when the Juju agent running the hook does not support the feature.
+func (c *Client) requireFeature(feature Feature) error {
+	version, err := c.GetJujuVersion()
+	if err != nil {
+		return nil
+	}
+
+	if version.Supports(feature) {
+		return nil
+	}
+
+	minimum, _ := MinimumJujuVersion(feature)
+
+	return fmt.Errorf("%w: %s requires Juju %s or later, running %s", ErrUnsupported, feature, minimum, version)
+}
+
+// featureCheckingCommandRunner fails hook tool calls that need a feature the Juju agent does not support.
+type featureCheckingCommandRunner struct {
+	runner CommandRunner
+	client *Client
+}
+
+func (r *featureCheckingCommandRunner) Run(name string, args ...string) ([]byte, error) {
+	if feature, ok := RequiredFeature(name, args...); ok {
+		err := r.client.requireFeature(feature)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.runner.Run(name, args...)
+}
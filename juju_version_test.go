@@ -0,0 +1,201 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestParseJujuVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected goops.JujuVersion
+	}{
+		{input: "3.6.0", expected: goops.JujuVersion{Major: 3, Minor: 6}},
+		{input: "3.6.12", expected: goops.JujuVersion{Major: 3, Minor: 6, Patch: 12}},
+		{input: "3.6-beta2", expected: goops.JujuVersion{Major: 3, Minor: 6, Tag: "beta", Patch: 2}},
+		{input: "3.6.1.2", expected: goops.JujuVersion{Major: 3, Minor: 6, Patch: 1, Build: 2}},
+	}
+
+	for _, tt := range tests {
+		version, err := goops.ParseJujuVersion(tt.input)
+		if err != nil {
+			t.Fatalf("ParseJujuVersion(%q) returned an error: %v", tt.input, err)
+		}
+
+		if version != tt.expected {
+			t.Errorf("ParseJujuVersion(%q) = %+v, expected %+v", tt.input, version, tt.expected)
+		}
+
+		if version.String() != tt.input {
+			t.Errorf("expected String() to return %q, got %q", tt.input, version.String())
+		}
+	}
+}
+
+func TestParseJujuVersion_Invalid(t *testing.T) {
+	for _, input := range []string{"", "3", "3.6", "three.six.zero", "3.6.0-beta"} {
+		_, err := goops.ParseJujuVersion(input)
+		if err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestJujuVersionCompare(t *testing.T) {
+	ordered := []string{"2.9.44", "3.1-beta1", "3.1-rc1", "3.1.0", "3.1.0.1", "3.1.1", "3.6.0"}
+
+	for i := range len(ordered) - 1 {
+		older, _ := goops.ParseJujuVersion(ordered[i])
+		newer, _ := goops.ParseJujuVersion(ordered[i+1])
+
+		if older.Compare(newer) != -1 {
+			t.Errorf("expected %s to be older than %s", older, newer)
+		}
+
+		if newer.Compare(older) != 1 {
+			t.Errorf("expected %s to be newer than %s", newer, older)
+		}
+
+		if older.Compare(older) != 0 {
+			t.Errorf("expected %s to equal itself", older)
+		}
+	}
+}
+
+func TestJujuVersionSupports(t *testing.T) {
+	version, _ := goops.ParseJujuVersion("3.3.5")
+
+	if !version.Supports(goops.FeatureSecrets) {
+		t.Errorf("expected 3.3.5 to support secrets")
+	}
+
+	if version.Supports(goops.FeatureSecretConfig) {
+		t.Errorf("expected 3.3.5 not to support secret config")
+	}
+
+	if version.Supports(goops.FeaturePebbleNotices) {
+		t.Errorf("expected 3.3.5 not to support pebble notices")
+	}
+}
+
+func TestJujuVersionSupports_SecretsBoundary(t *testing.T) {
+	version, _ := goops.ParseJujuVersion("3.0.2")
+
+	if version.Supports(goops.FeatureSecrets) {
+		t.Errorf("expected 3.0.2 not to support secrets")
+	}
+
+	version, _ = goops.ParseJujuVersion("3.0.3")
+
+	if !version.Supports(goops.FeatureSecrets) {
+		t.Errorf("expected 3.0.3 to support secrets")
+	}
+}
+
+func TestSupports_FromEnvironment(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{"JUJU_VERSION": "3.4.0"},
+	}))
+
+	if !client.Supports(goops.FeatureSecretConfig) {
+		t.Errorf("expected Juju 3.4.0 to support secret config")
+	}
+
+	client = goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{}))
+
+	if !client.Supports(goops.FeatureSecretConfig) {
+		t.Errorf("expected features to be assumed supported when JUJU_VERSION is not set")
+	}
+}
+
+func TestAddSecret_Unsupported(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{"JUJU_VERSION": "2.9.44"},
+		}),
+	)
+
+	_, err := client.AddSecret(&goops.AddSecretOptions{
+		Content: map[string]string{"password": "secret"},
+	})
+	if !errors.Is(err, goops.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+
+	expected := "failed to add secret: not supported by this Juju version: secrets requires Juju 3.0.3 or later, running 2.9.44"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+
+	if fakeRunner.Command != "" {
+		t.Errorf("expected no hook tool to be run, got %q", fakeRunner.Command)
+	}
+}
+
+func TestOpenPortRange_EndpointsUnsupported(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{"JUJU_VERSION": "2.9.44"},
+		}),
+	)
+
+	err := client.OpenPortRange(&goops.Port{Port: 80, Protocol: "tcp", Endpoints: []string{"website"}})
+	if !errors.Is(err, goops.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+
+	err = client.OpenPort(80, "tcp")
+	if err != nil {
+		t.Fatalf("expected opening a port without endpoints to succeed, got %v", err)
+	}
+
+	if fakeRunner.Command != "open-port" {
+		t.Errorf("expected command %q, got %q", "open-port", fakeRunner.Command)
+	}
+}
+
+func TestOpenedPorts_EndpointsUnsupported(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`["80/tcp"]`),
+	}
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{"JUJU_VERSION": "2.9.44"},
+		}),
+	)
+
+	ports, err := client.OpenedPorts()
+	if err != nil {
+		t.Fatalf("OpenedPorts returned an error: %v", err)
+	}
+
+	if len(fakeRunner.Args) != 1 || fakeRunner.Args[0] != "--format=json" {
+		t.Errorf("Expected arguments [--format=json], got %v", fakeRunner.Args)
+	}
+
+	if len(ports) != 1 || ports[0].String() != "80/tcp" {
+		t.Errorf("Expected [80/tcp], got %v", ports)
+	}
+}
+
+func TestRequiredFeature_OpenedPortsEndpoints(t *testing.T) {
+	feature, ok := goops.RequiredFeature("opened-ports", "--endpoints", "--format=json")
+	if !ok || feature != goops.FeatureOpenPortEndpoints {
+		t.Errorf("Expected %q, got %q", goops.FeatureOpenPortEndpoints, feature)
+	}
+
+	_, ok = goops.RequiredFeature("opened-ports", "--format=json")
+	if ok {
+		t.Errorf("Expected opened-ports without --endpoints to need no feature")
+	}
+}
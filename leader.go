@@ -10,8 +10,8 @@ const (
 )
 
 // IsLeader retrieves the unit's leadership status.
-func IsLeader() (bool, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) IsLeader() (bool, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--format=json"}
 
@@ -29,3 +29,8 @@ func IsLeader() (bool, error) {
 
 	return isLeader, nil
 }
+
+// IsLeader retrieves the unit's leadership status.
+func IsLeader() (bool, error) {
+	return defaultClient.IsLeader()
+}
@@ -23,35 +23,59 @@ var levelStrings = []string{
 	"ERROR",
 }
 
-func logf(level Level, format string, args ...any) {
-	commandRunner := GetCommandRunner()
+func (c *Client) logf(level Level, format string, args ...any) {
+	err := c.jujuLog(level, fmt.Sprintf(format, args...))
+	if err != nil {
+		log.Println("failed to run juju-log command:", err)
+	}
+}
 
-	message := fmt.Sprintf(format, args...)
+func (c *Client) jujuLog(level Level, message string) error {
+	commandRunner := c.commandRunner()
 
-	cmdArgs := []string{"--log-level=" + levelStrings[level], message}
+	cmdArgs := []string{"--log-level=" + levelStrings[level], c.sensitive.redact(message)}
 
 	_, err := commandRunner.Run(jujuLogCommand, cmdArgs...)
-	if err != nil {
-		log.Println("failed to run juju-log command:", err)
-	}
+
+	return err
+}
+
+// LogDebugf logs a debug message. Log messages can be read using `juju debug-log`.
+func (c *Client) LogDebugf(format string, args ...any) {
+	c.logf(Debug, format, args...)
 }
 
 // LogDebugf logs a debug message. Log messages can be read using `juju debug-log`.
 func LogDebugf(format string, args ...any) {
-	logf(Debug, format, args...)
+	defaultClient.LogDebugf(format, args...)
+}
+
+// LogInfof logs an informational message. Log messages can be read using `juju debug-log`.
+func (c *Client) LogInfof(format string, args ...any) {
+	c.logf(Info, format, args...)
 }
 
 // LogInfof logs an informational message. Log messages can be read using `juju debug-log`.
 func LogInfof(format string, args ...any) {
-	logf(Info, format, args...)
+	defaultClient.LogInfof(format, args...)
+}
+
+// LogWarningf logs a warning message. Log messages can be read using `juju debug-log`.
+func (c *Client) LogWarningf(format string, args ...any) {
+	c.logf(Warning, format, args...)
 }
 
 // LogWarningf logs a warning message. Log messages can be read using `juju debug-log`.
 func LogWarningf(format string, args ...any) {
-	logf(Warning, format, args...)
+	defaultClient.LogWarningf(format, args...)
+}
+
+// LogErrorf logs an error message. Log messages can be read using `juju debug-log`.
+func (c *Client) LogErrorf(format string, args ...any) {
+	c.logf(Error, format, args...)
 }
 
 // LogErrorf logs an error message. Log messages can be read using `juju debug-log`.
 func LogErrorf(format string, args ...any) {
-	logf(Error, format, args...)
+	defaultClient.LogErrorf(format, args...)
 }
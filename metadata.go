@@ -16,50 +16,115 @@ type Container struct {
 	Resource string  `yaml:"resource"`
 }
 
+type RelationScope string
+
+const (
+	ScopeGlobal    RelationScope = "global"
+	ScopeContainer RelationScope = "container"
+)
+
+// Integration describes a relation endpoint.
+// Limit is the maximum number of relations on the endpoint, 0 meaning no limit.
 type Integration struct {
-	Interface string `yaml:"interface"`
+	Interface string        `yaml:"interface"`
+	Limit     int           `yaml:"limit,omitempty"`
+	Optional  bool          `yaml:"optional,omitempty"`
+	Scope     RelationScope `yaml:"scope,omitempty"`
 }
 
 type Resource struct {
 	Description    string `yaml:"description"`
 	Type           string `yaml:"type"`
+	Filename       string `yaml:"filename,omitempty"`
 	UpstreamSource string `yaml:"upstream-source"`
 }
 
+type StorageMultiple struct {
+	Range string `yaml:"range"`
+}
+
 type Storage struct {
-	MinimumSize string `yaml:"minimum-size"`
+	Description string           `yaml:"description,omitempty"`
+	Location    string           `yaml:"location,omitempty"`
+	MinimumSize string           `yaml:"minimum-size"`
+	Multiple    *StorageMultiple `yaml:"multiple,omitempty"`
+	Properties  []string         `yaml:"properties,omitempty"`
+	ReadOnly    bool             `yaml:"read-only,omitempty"`
+	Shared      bool             `yaml:"shared,omitempty"`
+	Type        string           `yaml:"type"`
+}
+
+type Device struct {
+	Description string `yaml:"description,omitempty"`
 	Type        string `yaml:"type"`
+	CountMin    int    `yaml:"countmin,omitempty"`
+	CountMax    int    `yaml:"countmax,omitempty"`
 }
 
+// Metadata describes a charm, as declared in its metadata.yaml or in the unified charmcraft.yaml.
+// Assumes holds feature names and nested any-of/all-of expressions as written in the file.
+// ExtraBindings maps the names of the extra bindings to their (null) value.
 type Metadata struct {
-	Containers  map[string]Container   `yaml:"containers"`
-	Description string                 `yaml:"description"`
-	Name        string                 `yaml:"name"`
-	Provides    map[string]Integration `yaml:"provides"`
-	Resources   map[string]Resource    `yaml:"resources"`
-	Storage     map[string]Storage     `yaml:"storage"`
-	Summary     string                 `yaml:"summary"`
+	Assumes       []any                  `yaml:"assumes,omitempty"`
+	CharmUser     string                 `yaml:"charm-user,omitempty"`
+	Containers    map[string]Container   `yaml:"containers"`
+	Description   string                 `yaml:"description"`
+	Devices       map[string]Device      `yaml:"devices,omitempty"`
+	DisplayName   string                 `yaml:"display-name,omitempty"`
+	Docs          string                 `yaml:"docs,omitempty"`
+	ExtraBindings map[string]any         `yaml:"extra-bindings,omitempty"`
+	Maintainers   []string               `yaml:"maintainers,omitempty"`
+	Name          string                 `yaml:"name"`
+	Peers         map[string]Integration `yaml:"peers"`
+	Provides      map[string]Integration `yaml:"provides"`
+	Requires      map[string]Integration `yaml:"requires"`
+	Resources     map[string]Resource    `yaml:"resources"`
+	Storage       map[string]Storage     `yaml:"storage"`
+	Subordinate   bool                   `yaml:"subordinate,omitempty"`
+	Summary       string                 `yaml:"summary"`
+	Terms         []string               `yaml:"terms,omitempty"`
 }
 
 // ReadMetadata reads the metadata.yaml file from the charm directory and unmarshals it into a Metadata struct.
-func ReadMetadata() (*Metadata, error) {
-	env := ReadEnv()
-
-	path := env.CharmDir + "/metadata.yaml"
-
-	envGetter := GetEnvGetter()
-
-	yamlFile, err := envGetter.ReadFile(path) // #nosec G304
+// Charms using the unified format have their metadata read from charmcraft.yaml instead.
+func (c *Client) ReadMetadata() (*Metadata, error) {
+	yamlFile, err := c.readCharmFile("metadata.yaml", "charmcraft.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read metadata file: %w", err)
 	}
 
-	var c Metadata
+	var metadata Metadata
 
-	err = yaml.Unmarshal(yamlFile, &c)
+	err = yaml.Unmarshal(yamlFile, &metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 	}
 
-	return &c, nil
+	return &metadata, nil
+}
+
+// ReadMetadata reads the metadata.yaml file from the charm directory and unmarshals it into a Metadata struct.
+// Charms using the unified format have their metadata read from charmcraft.yaml instead.
+func ReadMetadata() (*Metadata, error) {
+	return defaultClient.ReadMetadata()
+}
+
+// readCharmFile returns the content of the first of the named files found in the charm directory.
+func (c *Client) readCharmFile(names ...string) ([]byte, error) {
+	env := c.ReadEnv()
+
+	envGetter := c.envGetter
+
+	var lastErr error
+
+	for _, name := range names {
+		data, err := envGetter.ReadFile(env.CharmDir + "/" + name) // #nosec G304
+		if err == nil {
+			return data, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
 }
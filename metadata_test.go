@@ -151,3 +151,85 @@ func TestGetCharmMetadata_Success(t *testing.T) {
 		}
 	}
 }
+
+const exampleCharmcraftMetadata = `
+name: example
+type: charm
+summary: Example charm
+description: Example charm using the unified format
+subordinate: true
+assumes:
+  - juju >= 3.4
+  - any-of:
+      - k8s-api
+      - lxd
+requires:
+  database:
+    interface: postgresql_client
+    limit: 1
+    optional: true
+  juju-info:
+    interface: juju-info
+    scope: container
+extra-bindings:
+  metrics: null
+devices:
+  gpu:
+    type: nvidia.com/gpu
+    countmin: 1
+    countmax: 2
+storage:
+  data:
+    type: filesystem
+    location: /var/lib/example
+    multiple:
+      range: 1-3
+parts:
+  charm:
+    plugin: go
+`
+
+func TestReadMetadata_FallsBackToCharmcraft(t *testing.T) {
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{"JUJU_CHARM_DIR": "/charm"},
+		Files: map[string][]byte{
+			"/charm/charmcraft.yaml": []byte(exampleCharmcraftMetadata),
+		},
+	}))
+
+	meta, err := client.ReadMetadata()
+	if err != nil {
+		t.Fatalf("ReadMetadata failed: %v", err)
+	}
+
+	if meta.Name != "example" || !meta.Subordinate {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+
+	if len(meta.Assumes) != 2 || meta.Assumes[0] != "juju >= 3.4" {
+		t.Errorf("Assumes = %v; want 2 entries starting with juju >= 3.4", meta.Assumes)
+	}
+
+	database := meta.Requires["database"]
+	if database.Interface != "postgresql_client" || database.Limit != 1 || !database.Optional {
+		t.Errorf("Requires[\"database\"] = %+v; want limit 1 and optional", database)
+	}
+
+	if meta.Requires["juju-info"].Scope != goops.ScopeContainer {
+		t.Errorf("Requires[\"juju-info\"].Scope = %q; want %q", meta.Requires["juju-info"].Scope, goops.ScopeContainer)
+	}
+
+	if _, ok := meta.ExtraBindings["metrics"]; !ok {
+		t.Errorf("ExtraBindings = %v; want metrics", meta.ExtraBindings)
+	}
+
+	gpu := meta.Devices["gpu"]
+	if gpu.Type != "nvidia.com/gpu" || gpu.CountMin != 1 || gpu.CountMax != 2 {
+		t.Errorf("Devices[\"gpu\"] = %+v", gpu)
+	}
+
+	data := meta.Storage["data"]
+	if data.Location != "/var/lib/example" || data.Multiple == nil || data.Multiple.Range != "1-3" {
+		t.Errorf("Storage[\"data\"] = %+v", data)
+	}
+}
@@ -3,6 +3,7 @@ package goops
 import (
 	"encoding/json"
 	"fmt"
+	"net/netip"
 )
 
 const (
@@ -25,13 +26,38 @@ type Network struct {
 	EgressSubnets    []string      `json:"egress-subnets"`
 }
 
+// GetNetwork retrieves the network configuration for a given binding name.
+func (c *Client) GetNetwork(bindingName string) (*Network, error) {
+	return c.getNetwork(bindingName)
+}
+
 // GetNetwork retrieves the network configuration for a given binding name.
 func GetNetwork(bindingName string) (*Network, error) {
-	commandRunner := GetCommandRunner()
+	return defaultClient.GetNetwork(bindingName)
+}
+
+// GetNetworkForRelation retrieves the network configuration of a binding for a
+// specific relation, as advertised to the remote application. For cross-model
+// relations, the addresses can differ from the ones returned by GetNetwork.
+func (c *Client) GetNetworkForRelation(bindingName string, relationID string) (*Network, error) {
+	return c.getNetwork(bindingName, "-r="+relationID)
+}
+
+// GetNetworkForRelation retrieves the network configuration of a binding for a
+// specific relation, as advertised to the remote application. For cross-model
+// relations, the addresses can differ from the ones returned by GetNetwork.
+func GetNetworkForRelation(bindingName string, relationID string) (*Network, error) {
+	return defaultClient.GetNetworkForRelation(bindingName, relationID)
+}
+
+func (c *Client) getNetwork(bindingName string, flags ...string) (*Network, error) {
+	commandRunner := c.commandRunner()
 
 	var args []string
 
-	args = append(args, bindingName, "--format=json")
+	args = append(args, bindingName)
+	args = append(args, flags...)
+	args = append(args, "--format=json")
 
 	output, err := commandRunner.Run(networkGetCommand, args...)
 	if err != nil {
@@ -47,3 +73,34 @@ func GetNetwork(bindingName string) (*Network, error) {
 
 	return &network, nil
 }
+
+// PrimaryIngressAddress returns the first ingress address, which is the address
+// other units should use to reach the unit.
+func (n *Network) PrimaryIngressAddress() (netip.Addr, error) {
+	if len(n.IngressAddresses) == 0 {
+		return netip.Addr{}, fmt.Errorf("no ingress address")
+	}
+
+	address, err := netip.ParseAddr(n.IngressAddresses[0])
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to parse ingress address: %w", err)
+	}
+
+	return address, nil
+}
+
+// EgressSubnetPrefixes returns the subnets that traffic from the unit originates from.
+func (n *Network) EgressSubnetPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(n.EgressSubnets))
+
+	for _, subnet := range n.EgressSubnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse egress subnet: %w", err)
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
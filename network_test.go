@@ -1,6 +1,8 @@
 package goops_test
 
 import (
+	"net/netip"
+	"strings"
 	"testing"
 
 	"github.com/gruyaume/goops"
@@ -78,3 +80,48 @@ func TestNetworkGet_Success(t *testing.T) {
 		t.Errorf("Expected argument %q, got %q", "--format=json", fakeRunner.Args[1])
 	}
 }
+
+func TestNetworkGetForRelation_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"bind-addresses":[],"egress-subnets":["203.0.113.0/24","10.0.0.5/32"],"ingress-addresses":["203.0.113.10","10.0.0.5"]}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	network, err := client.GetNetworkForRelation("database", "database:3")
+	if err != nil {
+		t.Fatalf("GetNetworkForRelation returned an error: %v", err)
+	}
+
+	expectedArgs := []string{"database", "-r=database:3", "--format=json"}
+	if strings.Join(fakeRunner.Args, " ") != strings.Join(expectedArgs, " ") {
+		t.Errorf("Expected arguments %q, got %q", expectedArgs, fakeRunner.Args)
+	}
+
+	address, err := network.PrimaryIngressAddress()
+	if err != nil {
+		t.Fatalf("PrimaryIngressAddress returned an error: %v", err)
+	}
+
+	if address != netip.MustParseAddr("203.0.113.10") {
+		t.Errorf("Expected ingress address %s, got %s", "203.0.113.10", address)
+	}
+
+	prefixes, err := network.EgressSubnetPrefixes()
+	if err != nil {
+		t.Fatalf("EgressSubnetPrefixes returned an error: %v", err)
+	}
+
+	if len(prefixes) != 2 || prefixes[0] != netip.MustParsePrefix("203.0.113.0/24") {
+		t.Errorf("Expected egress subnets %v, got %v", network.EgressSubnets, prefixes)
+	}
+}
+
+func TestNetworkPrimaryIngressAddress_NoAddress(t *testing.T) {
+	network := &goops.Network{}
+
+	_, err := network.PrimaryIngressAddress()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+}
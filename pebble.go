@@ -6,19 +6,23 @@ import (
 	"github.com/canonical/pebble/client"
 )
 
-var defaultPebbleGetter PebbleGetter = &realPebbleGetter{}
-
 type PebbleGetter interface {
 	Pebble(container string) PebbleClient
 }
 
+// Pebble returns a PebbleClient for the specified container.
+func (c *Client) Pebble(container string) PebbleClient {
+	return c.pebbleGetter.Pebble(container)
+}
+
 // Pebble returns a PebbleClient for the specified container.
 func Pebble(container string) PebbleClient {
-	return defaultPebbleGetter.Pebble(container)
+	return defaultClient.Pebble(container)
 }
 
+// SetPebbleGetter replaces the Pebble getter of the default client.
 func SetPebbleGetter(getter PebbleGetter) {
-	defaultPebbleGetter = getter
+	defaultClient.pebbleGetter = getter
 }
 
 type PebbleClient interface {
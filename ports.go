@@ -3,6 +3,9 @@ package goops
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -19,119 +22,239 @@ const (
 	ProtocolICMP Protocol = "icmp"
 )
 
+// Port is a port, or a range of ports from Port to EndPort, opened by the unit.
+// EndPort is 0 for a single port, and both are ignored for icmp.
+// Endpoints lists the relation endpoints the port is opened for, all of them when empty.
 type Port struct {
-	Port     int
-	Protocol Protocol
+	Port      int
+	EndPort   int
+	Protocol  Protocol
+	Endpoints []string
+}
+
+// String returns the port in the format used by the port hook tools, for example
+// 80/tcp, 8000-8100/udp or icmp.
+func (p *Port) String() string {
+	if p.Protocol == ProtocolICMP {
+		return string(ProtocolICMP)
+	}
+
+	if p.EndPort != 0 && p.EndPort != p.Port {
+		return fmt.Sprintf("%d-%d/%s", p.Port, p.EndPort, p.Protocol)
+	}
+
+	return fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+}
+
+// ParsePort parses a port in the format used by the port hook tools, for example
+// 80/tcp, 8000-8100/udp or icmp.
+func ParsePort(s string) (*Port, error) {
+	if s == string(ProtocolICMP) {
+		return &Port{Protocol: ProtocolICMP}, nil
+	}
+
+	portRange, protocol, found := strings.Cut(s, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid port %q, expected <port>[-<port>]/<protocol>", s)
+	}
+
+	port := &Port{Protocol: Protocol(protocol)}
+
+	from, to, isRange := strings.Cut(portRange, "-")
+
+	var err error
+
+	port.Port, err = strconv.Atoi(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", s, err)
+	}
+
+	if isRange {
+		port.EndPort, err = strconv.Atoi(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", s, err)
+		}
+	}
+
+	err = port.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return port, nil
+}
+
+func (p *Port) validate() error {
+	switch p.Protocol {
+	case ProtocolTCP, ProtocolUDP:
+	case ProtocolICMP:
+		return nil
+	default:
+		return fmt.Errorf("invalid protocol: %s, must be one of tcp, udp, or icmp", p.Protocol)
+	}
+
+	if p.Port < 0 || p.Port > 65535 {
+		return fmt.Errorf("port %d is out of range", p.Port)
+	}
+
+	if p.EndPort != 0 && (p.EndPort < p.Port || p.EndPort > 65535) {
+		return fmt.Errorf("port range %d-%d is invalid", p.Port, p.EndPort)
+	}
+
+	return nil
+}
+
+// key identifies the port range, protocol and endpoints of a port.
+func (p *Port) key() string {
+	endpoints := append([]string(nil), p.Endpoints...)
+	sort.Strings(endpoints)
+
+	return p.String() + " " + strings.Join(endpoints, ",")
+}
+
+func (p *Port) args() []string {
+	args := []string{p.String()}
+
+	if len(p.Endpoints) > 0 {
+		args = append(args, "--endpoints="+strings.Join(p.Endpoints, ","))
+	}
+
+	return args
 }
 
 // SetPorts sets the desired ports for the unit.
-// It opens ports that are desired but not currently opened, and closes ports that are currently opened but not desired.
-func SetPorts(ports []*Port) error {
-	openedPorts, err := OpenedPorts()
+// It closes ports that are currently opened but not desired, and opens ports that are desired but not currently opened.
+// Ports are compared by range, protocol and endpoints, so changing the range or the endpoints of a port re-opens it.
+func (c *Client) SetPorts(ports []*Port) error {
+	openedPorts, err := c.OpenedPorts()
 	if err != nil {
 		return fmt.Errorf("failed to get opened ports: %w", err)
 	}
 
-	desiredMap := make(map[string]*Port)
-
+	desiredMap := make(map[string]bool)
 	for _, port := range ports {
-		key := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
-		desiredMap[key] = port
+		desiredMap[port.key()] = true
 	}
 
-	openedMap := make(map[string]*Port)
-
+	openedMap := make(map[string]bool)
 	for _, port := range openedPorts {
-		key := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
-		openedMap[key] = port
+		openedMap[port.key()] = true
 	}
 
-	// Open ports that are desired but not currently opened.
-	for key, port := range desiredMap {
-		if _, exists := openedMap[key]; !exists {
-			if err := OpenPort(port.Port, port.Protocol); err != nil {
-				return fmt.Errorf("failed to open port %s: %w", key, err)
+	// Close ports first, so that a port re-opened with a different range does not conflict with its previous range.
+	for _, port := range openedPorts {
+		if !desiredMap[port.key()] {
+			if err := c.ClosePortRange(port); err != nil {
+				return fmt.Errorf("failed to close port %s: %w", port, err)
 			}
 		}
 	}
 
-	// Close ports that are currently opened but not desired.
-	for key, port := range openedMap {
-		if _, exists := desiredMap[key]; !exists {
-			if err := ClosePort(port.Port, port.Protocol); err != nil {
-				return fmt.Errorf("failed to close port %s: %w", key, err)
+	for _, port := range ports {
+		if !openedMap[port.key()] {
+			if err := c.OpenPortRange(port); err != nil {
+				return fmt.Errorf("failed to open port %s: %w", port, err)
 			}
+
+			openedMap[port.key()] = true
 		}
 	}
 
 	return nil
 }
 
+// SetPorts sets the desired ports for the unit.
+// It closes ports that are currently opened but not desired, and opens ports that are desired but not currently opened.
+// Ports are compared by range, protocol and endpoints, so changing the range or the endpoints of a port re-opens it.
+func SetPorts(ports []*Port) error {
+	return defaultClient.SetPorts(ports)
+}
+
 // OpenPort registers a request to open the specified port.
 // The port must be between 0 and 65535, and the protocol must be one of tcp, udp, or icmp.
 // If the protocol is icmp, the port argument is ignored.
-func OpenPort(port int, protocol Protocol) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) OpenPort(port int, protocol Protocol) error {
+	return c.OpenPortRange(&Port{Port: port, Protocol: protocol})
+}
 
-	if port < 0 || port > 65535 {
-		return fmt.Errorf("port %d is out of range", port)
-	}
+// OpenPort registers a request to open the specified port.
+// The port must be between 0 and 65535, and the protocol must be one of tcp, udp, or icmp.
+// If the protocol is icmp, the port argument is ignored.
+func OpenPort(port int, protocol Protocol) error {
+	return defaultClient.OpenPort(port, protocol)
+}
 
-	arg := fmt.Sprintf("%d/%s", port, protocol)
+// OpenPortRange registers a request to open a port or a range of ports, for all
+// endpoints or only for the endpoints listed in port.
+func (c *Client) OpenPortRange(port *Port) error {
+	commandRunner := c.commandRunner()
 
-	switch protocol {
-	case ProtocolTCP, ProtocolUDP:
-	case ProtocolICMP:
-		arg = "icmp"
-	default:
-		return fmt.Errorf("invalid protocol: %s, must be one of tcp, udp, or icmp", protocol)
+	err := port.validate()
+	if err != nil {
+		return err
 	}
 
-	args := []string{arg}
-
-	_, err := commandRunner.Run(openPortCommand, args...)
+	_, err = commandRunner.Run(openPortCommand, port.args()...)
 	if err != nil {
-		return fmt.Errorf("failed to open port %d/%s: %w", port, protocol, err)
+		return fmt.Errorf("failed to open port %s: %w", port, err)
 	}
 
 	return nil
 }
 
+// OpenPortRange registers a request to open a port or a range of ports, for all
+// endpoints or only for the endpoints listed in port.
+func OpenPortRange(port *Port) error {
+	return defaultClient.OpenPortRange(port)
+}
+
 // ClosePort registers a request to close the specified port.
 // The port must be between 0 and 65535, and the protocol must be one of tcp, udp, or icmp.
 // If the protocol is icmp, the port argument is ignored.
-func ClosePort(port int, protocol Protocol) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) ClosePort(port int, protocol Protocol) error {
+	return c.ClosePortRange(&Port{Port: port, Protocol: protocol})
+}
 
-	if port < 0 || port > 65535 {
-		return fmt.Errorf("port %d is out of range", port)
-	}
+// ClosePort registers a request to close the specified port.
+// The port must be between 0 and 65535, and the protocol must be one of tcp, udp, or icmp.
+// If the protocol is icmp, the port argument is ignored.
+func ClosePort(port int, protocol Protocol) error {
+	return defaultClient.ClosePort(port, protocol)
+}
 
-	arg := fmt.Sprintf("%d/%s", port, protocol)
+// ClosePortRange registers a request to close a port or a range of ports, for all
+// endpoints or only for the endpoints listed in port.
+func (c *Client) ClosePortRange(port *Port) error {
+	commandRunner := c.commandRunner()
 
-	switch protocol {
-	case ProtocolTCP, ProtocolUDP:
-	case ProtocolICMP:
-		arg = "icmp"
-	default:
-		return fmt.Errorf("invalid protocol: %s, must be one of tcp, udp, or icmp", protocol)
+	err := port.validate()
+	if err != nil {
+		return err
 	}
 
-	args := []string{arg}
-
-	_, err := commandRunner.Run(closePortCommand, args...)
+	_, err = commandRunner.Run(closePortCommand, port.args()...)
 	if err != nil {
-		return fmt.Errorf("failed to open port %d/%s: %w", port, protocol, err)
+		return fmt.Errorf("failed to close port %s: %w", port, err)
 	}
 
 	return nil
 }
 
-// List all ports opened by the unit.
-func OpenedPorts() ([]*Port, error) {
-	commandRunner := GetCommandRunner()
+// ClosePortRange registers a request to close a port or a range of ports, for all
+// endpoints or only for the endpoints listed in port.
+func ClosePortRange(port *Port) error {
+	return defaultClient.ClosePortRange(port)
+}
+
+// List all ports opened by the unit, with the endpoints they are opened for.
+// Endpoints are left empty when the Juju agent does not support them.
+func (c *Client) OpenedPorts() ([]*Port, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--format=json"}
+	if c.Supports(FeatureOpenPortEndpoints) {
+		args = []string{"--endpoints", "--format=json"}
+	}
 
 	output, err := commandRunner.Run(openedPortsCommand, args...)
 	if err != nil {
@@ -148,15 +271,47 @@ func OpenedPorts() ([]*Port, error) {
 	var openedPorts []*Port
 
 	for _, portString := range openedPortsString {
-		var port Port
-
-		_, err := fmt.Sscanf(portString, "%d/%s", &port.Port, &port.Protocol)
+		port, err := parseOpenedPort(portString)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse port %s: %w", portString, err)
 		}
 
-		openedPorts = append(openedPorts, &port)
+		openedPorts = append(openedPorts, port)
 	}
 
 	return openedPorts, nil
 }
+
+// List all ports opened by the unit, with the endpoints they are opened for.
+// Endpoints are left empty when the Juju agent does not support them.
+func OpenedPorts() ([]*Port, error) {
+	return defaultClient.OpenedPorts()
+}
+
+// parseOpenedPort parses a port listed by opened-ports --endpoints, for example
+// "80/tcp (*)" for a port opened for all endpoints or "8000-8100/tcp (website,admin)".
+func parseOpenedPort(s string) (*Port, error) {
+	portString, endpointList, hasEndpoints := strings.Cut(s, " ")
+
+	port, err := ParsePort(portString)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasEndpoints {
+		return port, nil
+	}
+
+	endpointList = strings.TrimSpace(endpointList)
+	endpointList = strings.TrimPrefix(endpointList, "(")
+	endpointList = strings.TrimSuffix(endpointList, ")")
+
+	for _, endpoint := range strings.Split(endpointList, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint != "" && endpoint != "*" {
+			port.Endpoints = append(port.Endpoints, endpoint)
+		}
+	}
+
+	return port, nil
+}
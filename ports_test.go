@@ -223,3 +223,102 @@ func TestClosePortInvalidProtocol_Failure(t *testing.T) {
 		t.Fatalf("Expected no arguments, got %d", len(fakeRunner.Args))
 	}
 }
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"80/tcp", "80/tcp"},
+		{"8000-8100/udp", "8000-8100/udp"},
+		{"8000-8000/tcp", "8000/tcp"},
+		{"icmp", "icmp"},
+	}
+
+	for _, tt := range tests {
+		port, err := goops.ParsePort(tt.input)
+		if err != nil {
+			t.Fatalf("ParsePort(%q) returned an error: %v", tt.input, err)
+		}
+
+		if port.String() != tt.expected {
+			t.Errorf("Expected ParsePort(%q) to be %q, got %q", tt.input, tt.expected, port.String())
+		}
+	}
+
+	for _, input := range []string{"80", "80/sctp", "8100-8000/tcp", "70000/tcp"} {
+		_, err := goops.ParsePort(input)
+		if err == nil {
+			t.Errorf("Expected ParsePort(%q) to return an error", input)
+		}
+	}
+}
+
+func TestOpenPortRangeWithEndpoints_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	err := client.OpenPortRange(&goops.Port{
+		Port:      8000,
+		EndPort:   8100,
+		Protocol:  goops.ProtocolTCP,
+		Endpoints: []string{"website", "admin"},
+	})
+	if err != nil {
+		t.Fatalf("OpenPortRange returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "open-port" {
+		t.Errorf("Expected command %q, got %q", "open-port", fakeRunner.Command)
+	}
+
+	if len(fakeRunner.Args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(fakeRunner.Args))
+	}
+
+	if fakeRunner.Args[0] != "8000-8100/tcp" {
+		t.Errorf("Expected argument %q, got %q", "8000-8100/tcp", fakeRunner.Args[0])
+	}
+
+	if fakeRunner.Args[1] != "--endpoints=website,admin" {
+		t.Errorf("Expected argument %q, got %q", "--endpoints=website,admin", fakeRunner.Args[1])
+	}
+}
+
+func TestOpenedPorts_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`["80/tcp (*)","8000-8100/udp (website, admin)","icmp (*)"]`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	ports, err := client.OpenedPorts()
+	if err != nil {
+		t.Fatalf("OpenedPorts returned an error: %v", err)
+	}
+
+	if fakeRunner.Args[0] != "--endpoints" {
+		t.Errorf("Expected argument %q, got %q", "--endpoints", fakeRunner.Args[0])
+	}
+
+	if len(ports) != 3 {
+		t.Fatalf("Expected 3 ports, got %d", len(ports))
+	}
+
+	if ports[0].String() != "80/tcp" || len(ports[0].Endpoints) != 0 {
+		t.Errorf("Expected 80/tcp for all endpoints, got %s for %v", ports[0], ports[0].Endpoints)
+	}
+
+	if ports[1].Port != 8000 || ports[1].EndPort != 8100 || ports[1].Protocol != goops.ProtocolUDP {
+		t.Errorf("Expected 8000-8100/udp, got %s", ports[1])
+	}
+
+	if len(ports[1].Endpoints) != 2 || ports[1].Endpoints[0] != "website" || ports[1].Endpoints[1] != "admin" {
+		t.Errorf("Expected endpoints [website admin], got %v", ports[1].Endpoints)
+	}
+
+	if ports[2].Protocol != goops.ProtocolICMP {
+		t.Errorf("Expected icmp, got %s", ports[2])
+	}
+}
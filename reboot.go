@@ -5,8 +5,8 @@ const (
 )
 
 // Reboot causes the host machine to reboot, after stopping all containers hosted on the machine.
-func Reboot(now bool) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) Reboot(now bool) error {
+	commandRunner := c.commandRunner()
 
 	var args []string
 	if now {
@@ -20,3 +20,8 @@ func Reboot(now bool) error {
 
 	return nil
 }
+
+// Reboot causes the host machine to reboot, after stopping all containers hosted on the machine.
+func Reboot(now bool) error {
+	return defaultClient.Reboot(now)
+}
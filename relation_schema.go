@@ -0,0 +1,438 @@
+package goops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type RelationRole string
+
+const (
+	RoleProvider RelationRole = "provider"
+	RoleRequirer RelationRole = "requirer"
+	RolePeer     RelationRole = "peer"
+)
+
+type DataBagKind string
+
+const (
+	AppDataBag  DataBagKind = "app"
+	UnitDataBag DataBagKind = "unit"
+)
+
+// InvalidKey describes a databag key that does not satisfy its schema.
+type InvalidKey struct {
+	Key    string
+	Reason string
+}
+
+// DataBagSchema validates the content of a relation databag.
+type DataBagSchema interface {
+	Validate(data map[string]string) []InvalidKey
+}
+
+// DataBagSchemas holds the schemas of the application and unit databags written by one side of a relation.
+// A nil schema disables validation for that databag.
+type DataBagSchemas struct {
+	App  DataBagSchema
+	Unit DataBagSchema
+}
+
+// InterfaceSchema describes the databags of both sides of a relation interface.
+// Peer describes the databags of peer relations using the interface.
+type InterfaceSchema struct {
+	Provider DataBagSchemas
+	Requirer DataBagSchemas
+	Peer     DataBagSchemas
+}
+
+func (s InterfaceSchema) schemaFor(role RelationRole, bag DataBagKind) DataBagSchema {
+	schemas := s.Provider
+
+	switch role {
+	case RoleRequirer:
+		schemas = s.Requirer
+	case RolePeer:
+		schemas = s.Peer
+	}
+
+	if bag == AppDataBag {
+		return schemas.App
+	}
+
+	return schemas.Unit
+}
+
+// RelationDataValidationError is returned when relation data read from or written to
+// a databag does not satisfy the schema registered for its interface.
+type RelationDataValidationError struct {
+	RelationID  string
+	Interface   string
+	Role        RelationRole // Side of the relation that owns the databag
+	Bag         DataBagKind
+	Write       bool // Whether the charm was writing the data rather than reading it
+	InvalidKeys []InvalidKey
+}
+
+func (e *RelationDataValidationError) Error() string {
+	keys := make([]string, 0, len(e.InvalidKeys))
+	for _, invalidKey := range e.InvalidKeys {
+		keys = append(keys, fmt.Sprintf("%s: %s", invalidKey.Key, invalidKey.Reason))
+	}
+
+	return fmt.Sprintf("invalid %s %s databag for interface %s in relation %s: %s", e.Role, e.Bag, e.Interface, e.RelationID, strings.Join(keys, "; "))
+}
+
+// interfaceSchemaRegistry holds the interface schemas registered on a client.
+type interfaceSchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]InterfaceSchema
+}
+
+func (r *interfaceSchemaRegistry) register(interfaceName string, schema InterfaceSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.schemas == nil {
+		r.schemas = make(map[string]InterfaceSchema)
+	}
+
+	r.schemas[interfaceName] = schema
+}
+
+func (r *interfaceSchemaRegistry) unregister(interfaceName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.schemas, interfaceName)
+}
+
+func (r *interfaceSchemaRegistry) lookup(interfaceName string) (InterfaceSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[interfaceName]
+
+	return schema, ok
+}
+
+func (r *interfaceSchemaRegistry) empty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.schemas) == 0
+}
+
+func (r *interfaceSchemaRegistry) copyTo(other *interfaceSchemaRegistry) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for interfaceName, schema := range r.schemas {
+		other.register(interfaceName, schema)
+	}
+}
+
+// RegisterInterfaceSchema registers the databag schemas of a relation interface.
+// Once registered, relation data exchanged over endpoints using that interface is
+// validated when read with GetAppRelationData/GetUnitRelationData and when written
+// with SetAppRelationData/SetUnitRelationData. Reads only report the keys present in
+// the databag, as the other side may not have written all of it yet. Writes are
+// merged with the current content of the databag before being validated.
+func (c *Client) RegisterInterfaceSchema(interfaceName string, schema InterfaceSchema) {
+	c.schemas.register(interfaceName, schema)
+}
+
+// RegisterInterfaceSchema registers the databag schemas of a relation interface on the default client.
+// Integration libraries typically call it from an init function. Once registered,
+// relation data exchanged over endpoints using that interface is validated when
+// read with GetAppRelationData/GetUnitRelationData and when written with
+// SetAppRelationData/SetUnitRelationData. Reads only report the keys present in
+// the databag, as the other side may not have written all of it yet. Writes are
+// merged with the current content of the databag before being validated.
+func RegisterInterfaceSchema(interfaceName string, schema InterfaceSchema) {
+	defaultClient.RegisterInterfaceSchema(interfaceName, schema)
+}
+
+// UnregisterInterfaceSchema removes the schemas registered for a relation interface.
+func (c *Client) UnregisterInterfaceSchema(interfaceName string) {
+	c.schemas.unregister(interfaceName)
+}
+
+// UnregisterInterfaceSchema removes the schemas registered for a relation interface on the default client.
+func UnregisterInterfaceSchema(interfaceName string) {
+	defaultClient.UnregisterInterfaceSchema(interfaceName)
+}
+
+// relationInterface returns the interface and local role of the endpoint a relation ID belongs to.
+// Relation IDs have the form <endpoint>:<number>.
+func (c *Client) relationInterface(relationID string) (string, RelationRole, bool, error) {
+	endpoint, _, found := strings.Cut(relationID, ":")
+	if !found {
+		return "", "", false, nil
+	}
+
+	metadata, err := c.ReadMetadata()
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if integration, ok := metadata.Provides[endpoint]; ok {
+		return integration.Interface, RoleProvider, true, nil
+	}
+
+	if integration, ok := metadata.Requires[endpoint]; ok {
+		return integration.Interface, RoleRequirer, true, nil
+	}
+
+	if integration, ok := metadata.Peers[endpoint]; ok {
+		return integration.Interface, RolePeer, true, nil
+	}
+
+	return "", "", false, nil
+}
+
+func otherRole(role RelationRole) RelationRole {
+	switch role {
+	case RoleProvider:
+		return RoleRequirer
+	case RoleRequirer:
+		return RoleProvider
+	default:
+		return role
+	}
+}
+
+// validateRelationData validates databag content against the schema registered for the relation's interface.
+// local indicates whether the databag belongs to this application.
+func (c *Client) validateRelationData(relationID string, local bool, write bool, bag DataBagKind, data map[string]string) error {
+	if c.schemas.empty() {
+		return nil
+	}
+
+	interfaceName, role, ok, err := c.relationInterface(relationID)
+	if err != nil {
+		return fmt.Errorf("failed to validate relation data: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	schema, ok := c.schemas.lookup(interfaceName)
+	if !ok {
+		return nil
+	}
+
+	if !local {
+		role = otherRole(role)
+	}
+
+	bagSchema := schema.schemaFor(role, bag)
+	if bagSchema == nil {
+		return nil
+	}
+
+	if write {
+		current, err := c.localRelationData(relationID, bag)
+		if err != nil {
+			return err
+		}
+
+		data = mergeRelationData(current, data)
+	}
+
+	invalidKeys := bagSchema.Validate(data)
+	if !write {
+		invalidKeys = presentInvalidKeys(invalidKeys, data)
+	}
+
+	if len(invalidKeys) == 0 {
+		return nil
+	}
+
+	validationErr := &RelationDataValidationError{
+		RelationID:  relationID,
+		Interface:   interfaceName,
+		Role:        role,
+		Bag:         bag,
+		Write:       write,
+		InvalidKeys: invalidKeys,
+	}
+
+	if c.onInvalidRelationData != nil {
+		c.onInvalidRelationData(validationErr)
+	}
+
+	return validationErr
+}
+
+// presentInvalidKeys drops the invalid keys that are missing from data. A databag is
+// read before the other side has written all of it, for example during
+// relation-joined, so missing keys are only reported on writes.
+func presentInvalidKeys(invalidKeys []InvalidKey, data map[string]string) []InvalidKey {
+	var present []InvalidKey
+
+	for _, invalidKey := range invalidKeys {
+		if data[invalidKey.Key] != "" {
+			present = append(present, invalidKey)
+		}
+	}
+
+	return present
+}
+
+// localRelationData reads the current content of a local databag, without validating it.
+func (c *Client) localRelationData(relationID string, bag DataBagKind) (map[string]string, error) {
+	args := []string{"-r=" + relationID, "-", c.ReadEnv().UnitName}
+	if bag == AppDataBag {
+		args = append(args, "--app")
+	}
+
+	args = append(args, "--format=json")
+
+	output, err := c.commandRunner().Run(relationGetCommand, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relation data: %w", err)
+	}
+
+	var content map[string]string
+
+	err = json.Unmarshal(output, &content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse relation content: %w", err)
+	}
+
+	return content, nil
+}
+
+// mergeRelationData returns the databag content resulting from writing data over current,
+// where empty values delete their key, as relation-set does.
+func mergeRelationData(current map[string]string, data map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(data))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range data {
+		if value == "" {
+			delete(merged, key)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// isLocalUnit reports whether the unit belongs to the application running the hook.
+func (c *Client) isLocalUnit(unitID string) bool {
+	localApp, _, _ := strings.Cut(c.ReadEnv().UnitName, "/")
+	app, _, _ := strings.Cut(unitID, "/")
+
+	return app == localApp
+}
+
+type structSchema struct {
+	typ reflect.Type
+}
+
+// StructSchema returns a DataBagSchema derived from the struct type T.
+// Databag keys map to fields as in UnmarshalDataBag and every present value must
+// decode into its field. Fields can carry additional constraints in a validate tag:
+//
+//   - required: the key must be present and non-empty, checked on writes only
+//   - oneof=a b c: the value must be one of the listed values
+//
+// StructSchema panics when T is not a struct or uses an unknown constraint.
+func StructSchema[T any]() DataBagSchema {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("StructSchema requires a struct type, got %s", typ))
+	}
+
+	for _, field := range dataBagFields(typ) {
+		structField := typ.Field(field.index)
+
+		err := checkValidateTag(structField.Tag.Get("validate"))
+		if err != nil {
+			panic(fmt.Sprintf("StructSchema: field %s of %s: %v", structField.Name, typ, err))
+		}
+	}
+
+	return &structSchema{typ: typ}
+}
+
+// checkValidateTag reports constraints of a validate tag that structSchema does not support.
+func checkValidateTag(tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	for _, constraint := range strings.Split(tag, ",") {
+		name, argument, _ := strings.Cut(constraint, "=")
+
+		switch name {
+		case "required":
+		case "oneof":
+			if strings.TrimSpace(argument) == "" {
+				return fmt.Errorf("constraint %q needs a list of values", constraint)
+			}
+		default:
+			return fmt.Errorf("unknown constraint %q", constraint)
+		}
+	}
+
+	return nil
+}
+
+func (s *structSchema) Validate(data map[string]string) []InvalidKey {
+	var invalidKeys []InvalidKey
+
+	err := UnmarshalDataBag(data, reflect.New(s.typ).Interface())
+
+	var decodeErr *DataBagDecodeError
+	if errors.As(err, &decodeErr) {
+		for _, field := range decodeErr.Fields {
+			invalidKeys = append(invalidKeys, InvalidKey{
+				Key:    field.Key,
+				Reason: fmt.Sprintf("invalid value: %v", field.Err),
+			})
+		}
+	}
+
+	for _, field := range dataBagFields(s.typ) {
+		tag := s.typ.Field(field.index).Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+
+		value := data[field.key]
+
+		for _, constraint := range strings.Split(tag, ",") {
+			name, argument, _ := strings.Cut(constraint, "=")
+
+			switch name {
+			case "required":
+				if value == "" {
+					invalidKeys = append(invalidKeys, InvalidKey{Key: field.key, Reason: "required key is missing"})
+				}
+			case "oneof":
+				allowed := strings.Fields(argument)
+				if value != "" && !slices.Contains(allowed, value) {
+					invalidKeys = append(invalidKeys, InvalidKey{
+						Key:    field.key,
+						Reason: fmt.Sprintf("value %q is not one of %s", value, strings.Join(allowed, ", ")),
+					})
+				}
+			}
+		}
+	}
+
+	return invalidKeys
+}
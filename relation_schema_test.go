@@ -0,0 +1,202 @@
+package goops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+type exampleProviderAppData struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+	Port     int    `json:"port"`
+	Scheme   string `json:"scheme" validate:"oneof=http https"`
+}
+
+const exampleSchemaMetadata = `
+name: example
+requires:
+  backend:
+    interface: example-schema
+`
+
+func newSchemaClient(runner *FakeRunner, opts ...func(*goops.Client)) *goops.Client {
+	opts = append([]func(*goops.Client){
+		goops.WithCommandRunner(runner),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{
+				"JUJU_CHARM_DIR": "/charm",
+				"JUJU_UNIT_NAME": "example/0",
+			},
+			Files: map[string][]byte{
+				"/charm/metadata.yaml": []byte(exampleSchemaMetadata),
+			},
+		}),
+	}, opts...)
+
+	return goops.NewClient(opts...)
+}
+
+func TestGetAppRelationData_SchemaViolation(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"port":"not-a-number","scheme":"ftp"}`),
+		Err:    nil,
+	}
+
+	client := newSchemaClient(fakeRunner)
+	client.RegisterInterfaceSchema("example-schema", goops.InterfaceSchema{
+		Provider: goops.DataBagSchemas{
+			App: goops.StructSchema[exampleProviderAppData](),
+		},
+	})
+
+	_, err := client.GetAppRelationData("backend:1", "provider/0")
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a RelationDataValidationError, got %v", err)
+	}
+
+	if validationErr.Role != goops.RoleProvider {
+		t.Errorf("Expected role %q, got %q", goops.RoleProvider, validationErr.Role)
+	}
+
+	if validationErr.Bag != goops.AppDataBag {
+		t.Errorf("Expected bag %q, got %q", goops.AppDataBag, validationErr.Bag)
+	}
+
+	invalidKeys := map[string]bool{}
+	for _, invalidKey := range validationErr.InvalidKeys {
+		invalidKeys[invalidKey.Key] = true
+	}
+
+	for _, key := range []string{"port", "scheme"} {
+		if !invalidKeys[key] {
+			t.Errorf("Expected key %q to be reported as invalid, got %v", key, validationErr.InvalidKeys)
+		}
+	}
+
+	if invalidKeys["endpoint"] {
+		t.Errorf("Expected missing required keys not to be reported on read, got %v", validationErr.InvalidKeys)
+	}
+}
+
+func TestGetAppRelationData_SchemaValid(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"endpoint":"example.com","port":"443","scheme":"https"}`),
+		Err:    nil,
+	}
+
+	client := newSchemaClient(fakeRunner)
+	client.RegisterInterfaceSchema("example-schema", goops.InterfaceSchema{
+		Provider: goops.DataBagSchemas{
+			App: goops.StructSchema[exampleProviderAppData](),
+		},
+	})
+
+	data, err := client.GetAppRelationData("backend:1", "provider/0")
+	if err != nil {
+		t.Fatalf("GetAppRelationData returned an error: %v", err)
+	}
+
+	if data["endpoint"] != "example.com" {
+		t.Errorf("Expected endpoint %q, got %q", "example.com", data["endpoint"])
+	}
+}
+
+func TestSetAppRelationData_SchemaViolation(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{}`),
+	}
+
+	client := newSchemaClient(fakeRunner)
+	client.RegisterInterfaceSchema("example-schema", goops.InterfaceSchema{
+		Requirer: goops.DataBagSchemas{
+			App: goops.StructSchema[exampleProviderAppData](),
+		},
+	})
+
+	err := client.SetAppRelationData("backend:1", map[string]string{"port": "443"})
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a RelationDataValidationError, got %v", err)
+	}
+
+	if !validationErr.Write {
+		t.Errorf("Expected the error to be reported as a write")
+	}
+
+	if fakeRunner.Command != "relation-get" {
+		t.Errorf("Expected relation-set not to be called, got %q", fakeRunner.Command)
+	}
+}
+
+func TestRegisterInterfaceSchema_PerClient(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"port":"not-a-number"}`),
+	}
+
+	client := newSchemaClient(fakeRunner)
+	client.RegisterInterfaceSchema("example-schema", goops.InterfaceSchema{
+		Provider: goops.DataBagSchemas{
+			App: goops.StructSchema[exampleProviderAppData](),
+		},
+	})
+
+	otherClient := newSchemaClient(fakeRunner)
+
+	_, err := otherClient.GetAppRelationData("backend:1", "provider/0")
+	if err != nil {
+		t.Fatalf("Expected schemas registered on another client to be ignored, got %v", err)
+	}
+
+	copiedClient := newSchemaClient(fakeRunner, goops.WithInterfaceSchemasFrom(client))
+
+	_, err = copiedClient.GetAppRelationData("backend:1", "provider/0")
+
+	var validationErr *goops.RelationDataValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected schemas copied with WithInterfaceSchemasFrom to apply, got %v", err)
+	}
+}
+
+func TestStructSchema_UnknownConstraint(t *testing.T) {
+	type typoData struct {
+		Endpoint string `json:"endpoint" validate:"requird"`
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected StructSchema to panic on an unknown constraint")
+		}
+	}()
+
+	goops.StructSchema[typoData]()
+}
+
+func TestGetAppRelationData_MetadataError(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"endpoint":"example.com"}`),
+	}
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithEnvGetter(&FakeEnvGetter{
+			Env: map[string]string{
+				"JUJU_CHARM_DIR": "/charm",
+				"JUJU_UNIT_NAME": "example/0",
+			},
+		}),
+	)
+	client.RegisterInterfaceSchema("example-schema", goops.InterfaceSchema{
+		Provider: goops.DataBagSchemas{
+			App: goops.StructSchema[exampleProviderAppData](),
+		},
+	})
+
+	_, err := client.GetAppRelationData("backend:1", "provider/0")
+	if err == nil {
+		t.Fatalf("Expected an error when the metadata cannot be read, got nil")
+	}
+}
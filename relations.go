@@ -2,6 +2,7 @@ package goops
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -21,8 +22,8 @@ const (
 // - SetUnitRelationData
 // - SetAppRelationData
 // - GetRelationModel
-func GetRelationIDs(name string) ([]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetRelationIDs(name string) ([]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{name, "--format=json"}
 
@@ -41,12 +42,24 @@ func GetRelationIDs(name string) ([]string, error) {
 	return relationIDs, nil
 }
 
+// GetRelationIDs retrieves the IDs of all relations for a given endpoint.
+// The output is useful as input to:
+// - ListRelationUnits
+// - GetAppRelationData
+// - GetUnitRelationData
+// - SetUnitRelationData
+// - SetAppRelationData
+// - GetRelationModel
+func GetRelationIDs(name string) ([]string, error) {
+	return defaultClient.GetRelationIDs(name)
+}
+
 // GetUnitRelationData retrieves the relation data for a specific unit in a relation by its ID.
 // unitID can either be:
 // - The remote unit ID which can be retrieved via goops.ListRelationUnits()
 // - The local unit ID which you can retrieve via goops.ReadEnv()
-func GetUnitRelationData(id string, unitID string) (map[string]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetUnitRelationData(id string, unitID string) (map[string]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-r=" + id, "-", unitID}
 
@@ -64,15 +77,28 @@ func GetUnitRelationData(id string, unitID string) (map[string]string, error) {
 		return nil, fmt.Errorf("failed to parse relation content: %w", err)
 	}
 
+	err = c.validateRelationData(id, c.isLocalUnit(unitID), false, UnitDataBag, relationContent)
+	if err != nil {
+		return nil, err
+	}
+
 	return relationContent, nil
 }
 
+// GetUnitRelationData retrieves the relation data for a specific unit in a relation by its ID.
+// unitID can either be:
+// - The remote unit ID which can be retrieved via goops.ListRelationUnits()
+// - The local unit ID which you can retrieve via goops.ReadEnv()
+func GetUnitRelationData(id string, unitID string) (map[string]string, error) {
+	return defaultClient.GetUnitRelationData(id, unitID)
+}
+
 // GetUnitRelationData retrieves the relation data for a specific app in a relation by its ID.
 // unitID can either be:
 // - The remote unit ID which can be retrieved via goops.ListRelationUnits()
 // - The local unit ID which you can retrieve via goops.ReadEnv()
-func GetAppRelationData(id string, unitID string) (map[string]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetAppRelationData(id string, unitID string) (map[string]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-r=" + id, "-", unitID, "--app"}
 
@@ -90,12 +116,25 @@ func GetAppRelationData(id string, unitID string) (map[string]string, error) {
 		return nil, fmt.Errorf("failed to parse relation content: %w", err)
 	}
 
+	err = c.validateRelationData(id, c.isLocalUnit(unitID), false, AppDataBag, relationContent)
+	if err != nil {
+		return nil, err
+	}
+
 	return relationContent, nil
 }
 
+// GetUnitRelationData retrieves the relation data for a specific app in a relation by its ID.
+// unitID can either be:
+// - The remote unit ID which can be retrieved via goops.ListRelationUnits()
+// - The local unit ID which you can retrieve via goops.ReadEnv()
+func GetAppRelationData(id string, unitID string) (map[string]string, error) {
+	return defaultClient.GetAppRelationData(id, unitID)
+}
+
 // ListRelationUnits lists all remote units in a relation by its ID.
-func ListRelationUnits(id string) ([]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) ListRelationUnits(id string) ([]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-r=" + id, "--format=json"}
 
@@ -114,9 +153,14 @@ func ListRelationUnits(id string) ([]string, error) {
 	return relationList, nil
 }
 
+// ListRelationUnits lists all remote units in a relation by its ID.
+func ListRelationUnits(id string) ([]string, error) {
+	return defaultClient.ListRelationUnits(id)
+}
+
 // GetRelationApp retrieves the remote application name for a relation by its ID.
-func GetRelationApp(id string) (string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetRelationApp(id string) (string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-r=" + id, "--app", "--format=json"}
 
@@ -135,15 +179,29 @@ func GetRelationApp(id string) (string, error) {
 	return relationApp, nil
 }
 
+// GetRelationApp retrieves the remote application name for a relation by its ID.
+func GetRelationApp(id string) (string, error) {
+	return defaultClient.GetRelationApp(id)
+}
+
 // SetUnitRelationData sets the local unit relation data in a relation by its ID.
-func SetUnitRelationData(id string, data map[string]string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetUnitRelationData(id string, data map[string]string) error {
+	commandRunner := c.commandRunner()
+
+	err := c.validateRelationData(id, true, true, UnitDataBag, data)
+	if err != nil {
+		return fmt.Errorf("failed to set relation data: %w", err)
+	}
 
 	args := []string{"-r=" + id}
 
-	for key, value := range data {
-		args = append(args, key+"="+value)
+	dataArgs, removeDataFile, err := c.relationDataArgs(data)
+	if err != nil {
+		return fmt.Errorf("failed to set relation data: %w", err)
 	}
+	defer removeDataFile()
+
+	args = append(args, dataArgs...)
 
 	output, err := commandRunner.Run(relationSetCommand, args...)
 	if err != nil {
@@ -157,17 +215,31 @@ func SetUnitRelationData(id string, data map[string]string) error {
 	return nil
 }
 
+// SetUnitRelationData sets the local unit relation data in a relation by its ID.
+func SetUnitRelationData(id string, data map[string]string) error {
+	return defaultClient.SetUnitRelationData(id, data)
+}
+
 // SetAppRelationData sets the local application relation data in a relation by its ID.
-func SetAppRelationData(id string, data map[string]string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetAppRelationData(id string, data map[string]string) error {
+	commandRunner := c.commandRunner()
+
+	err := c.validateRelationData(id, true, true, AppDataBag, data)
+	if err != nil {
+		return fmt.Errorf("failed to set relation data: %w", err)
+	}
 
 	args := []string{"-r=" + id}
 
 	args = append(args, "--app")
 
-	for key, value := range data {
-		args = append(args, key+"="+value)
+	dataArgs, removeDataFile, err := c.relationDataArgs(data)
+	if err != nil {
+		return fmt.Errorf("failed to set relation data: %w", err)
 	}
+	defer removeDataFile()
+
+	args = append(args, dataArgs...)
 
 	output, err := commandRunner.Run(relationSetCommand, args...)
 	if err != nil {
@@ -181,13 +253,47 @@ func SetAppRelationData(id string, data map[string]string) error {
 	return nil
 }
 
+// SetAppRelationData sets the local application relation data in a relation by its ID.
+func SetAppRelationData(id string, data map[string]string) error {
+	return defaultClient.SetAppRelationData(id, data)
+}
+
+// relationDataArgs returns the relation-set arguments for data. Large data, or data
+// holding sensitive values, is written to a file passed with --file, and the
+// returned function removes it.
+func (c *Client) relationDataArgs(data map[string]string) ([]string, func(), error) {
+	useFile := inlineContentSize(data) > maxInlineContentSize
+
+	for _, value := range data {
+		if c.sensitive.contains(value) {
+			useFile = true
+		}
+	}
+
+	if !useFile {
+		args := make([]string, 0, len(data))
+		for key, value := range data {
+			args = append(args, key+"="+value)
+		}
+
+		return args, func() {}, nil
+	}
+
+	dataFile, removeDataFile, err := writeContentFile(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []string{"--file=" + dataFile}, removeDataFile, nil
+}
+
 type RelationModel struct {
 	UUID string `json:"uuid"`
 }
 
 // GetRelationModel retrieves the relation model UUID for a relation by its ID.
-func GetRelationModelUUID(id string) (string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetRelationModelUUID(id string) (string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-r=" + id, "--format=json"}
 
@@ -205,3 +311,110 @@ func GetRelationModelUUID(id string) (string, error) {
 
 	return relationModel.UUID, nil
 }
+
+// GetRelationModel retrieves the relation model UUID for a relation by its ID.
+func GetRelationModelUUID(id string) (string, error) {
+	return defaultClient.GetRelationModelUUID(id)
+}
+
+// GetUnitRelationDataInto retrieves the relation data for a specific unit and decodes it into v using UnmarshalDataBag.
+func (c *Client) GetUnitRelationDataInto(id string, unitID string, v any) error {
+	data, err := c.GetUnitRelationData(id, unitID)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalDataBag(data, v)
+}
+
+// GetUnitRelationDataInto retrieves the relation data for a specific unit and decodes it into v using UnmarshalDataBag.
+func GetUnitRelationDataInto(id string, unitID string, v any) error {
+	return defaultClient.GetUnitRelationDataInto(id, unitID, v)
+}
+
+// GetAppRelationDataInto retrieves the relation data for a specific app and decodes it into v using UnmarshalDataBag.
+func (c *Client) GetAppRelationDataInto(id string, unitID string, v any) error {
+	data, err := c.GetAppRelationData(id, unitID)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalDataBag(data, v)
+}
+
+// GetAppRelationDataInto retrieves the relation data for a specific app and decodes it into v using UnmarshalDataBag.
+func GetAppRelationDataInto(id string, unitID string, v any) error {
+	return defaultClient.GetAppRelationDataInto(id, unitID, v)
+}
+
+// GetUnitRelationDataAsWithClient retrieves the relation data for a specific unit through c and decodes it into a T.
+// On a *DataBagDecodeError, the returned value holds every field that could be decoded.
+func GetUnitRelationDataAsWithClient[T any](c *Client, id string, unitID string) (*T, error) {
+	var v T
+
+	err := c.GetUnitRelationDataInto(id, unitID, &v)
+
+	var decodeErr *DataBagDecodeError
+	if err != nil && !errors.As(err, &decodeErr) {
+		return nil, err
+	}
+
+	return &v, err
+}
+
+// GetUnitRelationDataAs retrieves the relation data for a specific unit and decodes it into a T.
+// On a *DataBagDecodeError, the returned value holds every field that could be decoded.
+func GetUnitRelationDataAs[T any](id string, unitID string) (*T, error) {
+	return GetUnitRelationDataAsWithClient[T](defaultClient, id, unitID)
+}
+
+// GetAppRelationDataAsWithClient retrieves the relation data for a specific app through c and decodes it into a T.
+// On a *DataBagDecodeError, the returned value holds every field that could be decoded.
+func GetAppRelationDataAsWithClient[T any](c *Client, id string, unitID string) (*T, error) {
+	var v T
+
+	err := c.GetAppRelationDataInto(id, unitID, &v)
+
+	var decodeErr *DataBagDecodeError
+	if err != nil && !errors.As(err, &decodeErr) {
+		return nil, err
+	}
+
+	return &v, err
+}
+
+// GetAppRelationDataAs retrieves the relation data for a specific app and decodes it into a T.
+// On a *DataBagDecodeError, the returned value holds every field that could be decoded.
+func GetAppRelationDataAs[T any](id string, unitID string) (*T, error) {
+	return GetAppRelationDataAsWithClient[T](defaultClient, id, unitID)
+}
+
+// SetUnitRelationDataFrom encodes v using MarshalDataBag and sets it as the local unit relation data.
+func (c *Client) SetUnitRelationDataFrom(id string, v any) error {
+	data, err := MarshalDataBag(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode relation data: %w", err)
+	}
+
+	return c.SetUnitRelationData(id, data)
+}
+
+// SetUnitRelationDataFrom encodes v using MarshalDataBag and sets it as the local unit relation data.
+func SetUnitRelationDataFrom(id string, v any) error {
+	return defaultClient.SetUnitRelationDataFrom(id, v)
+}
+
+// SetAppRelationDataFrom encodes v using MarshalDataBag and sets it as the local application relation data.
+func (c *Client) SetAppRelationDataFrom(id string, v any) error {
+	data, err := MarshalDataBag(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode relation data: %w", err)
+	}
+
+	return c.SetAppRelationData(id, data)
+}
+
+// SetAppRelationDataFrom encodes v using MarshalDataBag and sets it as the local application relation data.
+func SetAppRelationDataFrom(id string, v any) error {
+	return defaultClient.SetAppRelationDataFrom(id, v)
+}
@@ -1,6 +1,7 @@
 package goops_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gruyaume/goops"
@@ -211,6 +212,56 @@ func TestRelationSet_Success(t *testing.T) {
 	}
 }
 
+func TestRelationSet_LargeDataUsesFile(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	chain := strings.Repeat("a", 40*1024)
+
+	err := client.SetAppRelationData("certificates:0", map[string]string{
+		"chain": chain,
+	})
+	if err != nil {
+		t.Fatalf("RelationSet returned an error: %v", err)
+	}
+
+	if len(fakeRunner.Args) != 3 {
+		t.Fatalf("Expected 3 arguments, got %d", len(fakeRunner.Args))
+	}
+
+	if !strings.HasPrefix(fakeRunner.Args[2], "--file=") {
+		t.Errorf("Expected data file arg, got %q", fakeRunner.Args[2])
+	}
+
+	expectedContent := "chain: " + chain + "\n"
+	if string(fakeRunner.FileContent) != expectedContent {
+		t.Errorf("Expected data file content of %d bytes, got %d bytes", len(expectedContent), len(fakeRunner.FileContent))
+	}
+}
+
+func TestRelationSet_SensitiveDataUsesFile(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+	client.MarkSensitive("pass1")
+
+	err := client.SetUnitRelationData("database:0", map[string]string{
+		"password": "pass1",
+	})
+	if err != nil {
+		t.Fatalf("RelationSet returned an error: %v", err)
+	}
+
+	if len(fakeRunner.Args) != 2 || !strings.HasPrefix(fakeRunner.Args[1], "--file=") {
+		t.Fatalf("Expected data file arg, got %q", fakeRunner.Args)
+	}
+
+	if string(fakeRunner.FileContent) != "password: pass1\n" {
+		t.Errorf("Expected data file content %q, got %q", "password: pass1\n", string(fakeRunner.FileContent))
+	}
+}
+
 func TestRelationModelGet_Success(t *testing.T) {
 	fakeRunner := &FakeRunner{
 		Output: []byte(`{"uuid":"e7ba04d1-b5f2-4769-8ae2-22e9119bca60"}`),
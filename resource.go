@@ -1,12 +1,49 @@
 package goops
 
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 const (
 	resourceGetCommand = "resource-get"
 )
 
+type ResourceType string
+
+const (
+	ResourceFile     ResourceType = "file"
+	ResourceOCIImage ResourceType = "oci-image"
+)
+
+// OCIImage holds the details of an oci-image resource, as written by Juju to the resource file.
+type OCIImage struct {
+	RegistryPath string `yaml:"registrypath"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+}
+
+// ResourceInfo describes a resource provided to the charm.
+// Path is the local path of the resource file. For oci-image resources, it is the
+// file holding the image details, which are parsed into Image.
+type ResourceInfo struct {
+	Name  string
+	Type  ResourceType
+	Path  string
+	Image *OCIImage
+}
+
 // GetResource retrieves the local path to a resource file for the given resource name.
-func GetResource(name string) (string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetResource(name string) (string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{name}
 
@@ -15,5 +52,124 @@ func GetResource(name string) (string, error) {
 		return "", err
 	}
 
-	return string(output), nil
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetResource retrieves the local path to a resource file for the given resource name.
+func GetResource(name string) (string, error) {
+	return defaultClient.GetResource(name)
+}
+
+// GetResourceInfo retrieves a resource, using the charm metadata to tell file
+// resources from oci-image resources. Resources that are not declared in the
+// metadata are treated as files. The registry password of oci-image resources
+// is marked sensitive.
+func (c *Client) GetResourceInfo(name string) (*ResourceInfo, error) {
+	resourceType, err := c.resourceType(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource type: %w", err)
+	}
+
+	path, err := c.GetResource(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+
+	info := &ResourceInfo{
+		Name: name,
+		Type: resourceType,
+		Path: path,
+	}
+
+	if resourceType != ResourceOCIImage {
+		return info, nil
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read oci-image resource: %w", err)
+	}
+
+	var image OCIImage
+
+	err = yaml.Unmarshal(data, &image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oci-image resource: %w", err)
+	}
+
+	c.sensitive.add(image.Password)
+
+	info.Image = &image
+
+	return info, nil
+}
+
+// GetResourceInfo retrieves a resource, using the charm metadata to tell file
+// resources from oci-image resources. Resources that are not declared in the
+// metadata are treated as files. The registry password of oci-image resources
+// is marked sensitive.
+func GetResourceInfo(name string) (*ResourceInfo, error) {
+	return defaultClient.GetResourceInfo(name)
+}
+
+func (c *Client) resourceType(name string) (ResourceType, error) {
+	metadata, err := c.ReadMetadata()
+	if errors.Is(err, fs.ErrNotExist) {
+		return ResourceFile, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	resource, ok := metadata.Resources[name]
+	if !ok || resource.Type == "" {
+		return ResourceFile, nil
+	}
+
+	return ResourceType(resource.Type), nil
+}
+
+// Size returns the size in bytes of the resource file.
+func (r *ResourceInfo) Size() (int64, error) {
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat resource %s: %w", r.Name, err)
+	}
+
+	return info.Size(), nil
+}
+
+// SHA384 returns the hex encoded SHA-384 digest of the resource file, which is
+// the fingerprint Juju records for resources.
+func (r *ResourceInfo) SHA384() (string, error) {
+	file, err := os.Open(r.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open resource %s: %w", r.Name, err)
+	}
+	defer file.Close()
+
+	hash := sha512.New384()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read resource %s: %w", r.Name, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifySHA384 checks that the SHA-384 digest of the resource file matches
+// the expected hex encoded digest.
+func (r *ResourceInfo) VerifySHA384(expected string) error {
+	digest, err := r.SHA384()
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(digest, expected) {
+		return fmt.Errorf("resource %s has SHA-384 digest %s, expected %s", r.Name, digest, expected)
+	}
+
+	return nil
 }
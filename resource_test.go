@@ -1,6 +1,8 @@
 package goops_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruyaume/goops"
@@ -35,3 +37,84 @@ func TestResourceGet_Success(t *testing.T) {
 		t.Errorf("Expected argument %q, got %q", "software", fakeRunner.Args[0])
 	}
 }
+
+func TestResourceGet_TrimsNewline(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte("/var/lib/juju/agents/unit-example-0/resources/software/software.zip\n"),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	result, err := client.GetResource("software")
+	if err != nil {
+		t.Fatalf("GetResource returned an error: %v", err)
+	}
+
+	if result != "/var/lib/juju/agents/unit-example-0/resources/software/software.zip" {
+		t.Errorf("Expected %q, got %q", "/var/lib/juju/agents/unit-example-0/resources/software/software.zip", result)
+	}
+}
+
+func TestGetResourceInfo_OCIImage(t *testing.T) {
+	imagePath := filepath.Join(t.TempDir(), "content.yaml")
+
+	err := os.WriteFile(imagePath, []byte(`{"registrypath":"ghcr.io/example/workload:1.0","username":"robot","password":"hunter2"}`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write image file: %v", err)
+	}
+
+	fakeRunner := &FakeRunner{
+		Output: []byte(imagePath + "\n"),
+	}
+	fakeEnv := &FakeEnvGetter{
+		Env: map[string]string{"JUJU_CHARM_DIR": "/charm"},
+		Files: map[string][]byte{
+			"/charm/metadata.yaml": []byte("name: example\nresources:\n  workload-image:\n    type: oci-image\n"),
+		},
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner), goops.WithEnvGetter(fakeEnv))
+
+	resource, err := client.GetResourceInfo("workload-image")
+	if err != nil {
+		t.Fatalf("GetResourceInfo returned an error: %v", err)
+	}
+
+	if resource.Type != goops.ResourceOCIImage {
+		t.Fatalf("Expected type %q, got %q", goops.ResourceOCIImage, resource.Type)
+	}
+
+	if resource.Image.RegistryPath != "ghcr.io/example/workload:1.0" || resource.Image.Username != "robot" || resource.Image.Password != "hunter2" {
+		t.Errorf("Unexpected image details %+v", resource.Image)
+	}
+}
+
+func TestResourceInfo_VerifySHA384(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "software.txt")
+
+	err := os.WriteFile(path, []byte("hello goops\n"), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write resource file: %v", err)
+	}
+
+	resource := &goops.ResourceInfo{Name: "software", Type: goops.ResourceFile, Path: path}
+
+	err = resource.VerifySHA384("55fb972381d098d7931503fc9fe80009eb5952111f1086fbcfedb109aa5b0a2cb314f714f0ff7d347e356fb8b828b5a4")
+	if err != nil {
+		t.Errorf("VerifySHA384 returned an error: %v", err)
+	}
+
+	err = resource.VerifySHA384("00")
+	if err == nil {
+		t.Error("Expected VerifySHA384 to fail for a different digest")
+	}
+
+	size, err := resource.Size()
+	if err != nil {
+		t.Fatalf("Size returned an error: %v", err)
+	}
+
+	if size != 12 {
+		t.Errorf("Expected size 12, got %d", size)
+	}
+}
@@ -2,12 +2,10 @@ package goops
 
 import (
 	"bytes"
-	"fmt"
+	"errors"
 	"os/exec"
 )
 
-var defaultRunner CommandRunner = &realHookCommand{}
-
 type realHookCommand struct{}
 
 func (r *realHookCommand) Run(name string, args ...string) ([]byte, error) {
@@ -18,7 +16,14 @@ func (r *realHookCommand) Run(name string, args ...string) ([]byte, error) {
 
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("command %s failed: %s: %w", name, stderr.String(), err)
+		exitCode := -1
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+
+		return nil, NewHookToolError(name, args, exitCode, stderr.String(), err)
 	}
 
 	return output, nil
@@ -30,10 +35,12 @@ type CommandRunner interface {
 	Run(name string, args ...string) ([]byte, error)
 }
 
+// GetCommandRunner returns the command runner of the default client.
 func GetCommandRunner() CommandRunner {
-	return defaultRunner
+	return defaultClient.runner
 }
 
+// SetCommandRunner replaces the command runner of the default client.
 func SetCommandRunner(runner CommandRunner) {
-	defaultRunner = runner
+	defaultClient.runner = runner
 }
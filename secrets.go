@@ -33,14 +33,17 @@ const (
 	RotateNever   SecretRotate = "never"
 )
 
+// SetSecretOptions holds the new content and options of a secret.
+// BinaryContent holds values that are not valid text, which are sent base64 encoded.
 type SetSecretOptions struct {
-	ID          string
-	Content     map[string]string
-	Description string
-	Expire      time.Time
-	Label       string
-	Owner       SecretOwner
-	Rotate      SecretRotate
+	ID            string
+	Content       map[string]string
+	BinaryContent map[string][]byte
+	Description   string
+	Expire        time.Time
+	Label         string
+	Owner         SecretOwner
+	Rotate        SecretRotate
 }
 
 type SecretOwner string
@@ -50,27 +53,38 @@ const (
 	OwnerUnit        SecretOwner = "unit"
 )
 
+// AddSecretOptions holds the content and options of a new secret.
+// BinaryContent holds values that are not valid text, which are sent base64 encoded.
 type AddSecretOptions struct {
-	Content     map[string]string
-	Description string
-	Expire      time.Time
-	Label       string
-	Owner       SecretOwner
-	Rotate      SecretRotate
+	Content       map[string]string
+	BinaryContent map[string][]byte
+	Description   string
+	Expire        time.Time
+	Label         string
+	Owner         SecretOwner
+	Rotate        SecretRotate
 }
 
 // AddSecret adds a new secret with the provided options.
-func AddSecret(opts *AddSecretOptions) (string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) AddSecret(opts *AddSecretOptions) (string, error) {
+	commandRunner := c.commandRunner()
 
-	if len(opts.Content) == 0 {
+	if len(opts.Content) == 0 && len(opts.BinaryContent) == 0 {
 		return "", fmt.Errorf("content cannot be empty")
 	}
 
-	var args []string
-	for key, value := range opts.Content {
-		args = append(args, key+"="+value)
+	fileContent := secretFileContent(opts.Content, opts.BinaryContent)
+
+	c.markSecretContent(fileContent)
+	c.markBinarySecretContent(opts.BinaryContent)
+
+	contentFile, removeContentFile, err := writeContentFile(fileContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to add secret: %w", err)
 	}
+	defer removeContentFile()
+
+	args := []string{"--file=" + contentFile}
 
 	if opts.Description != "" {
 		args = append(args, "--description="+opts.Description)
@@ -100,9 +114,14 @@ func AddSecret(opts *AddSecretOptions) (string, error) {
 	return string(output), nil
 }
 
+// AddSecret adds a new secret with the provided options.
+func AddSecret(opts *AddSecretOptions) (string, error) {
+	return defaultClient.AddSecret(opts)
+}
+
 // GetSecretByID retrieves the secret content by its ID.
-func GetSecretByID(id string, peek bool, refresh bool) (map[string]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetSecretByID(id string, peek bool, refresh bool) (map[string]string, error) {
+	commandRunner := c.commandRunner()
 
 	var args []string
 	args = append(args, id)
@@ -129,12 +148,19 @@ func GetSecretByID(id string, peek bool, refresh bool) (map[string]string, error
 		return nil, fmt.Errorf("failed to parse secret content: %w", err)
 	}
 
+	c.markSecretContent(secretContent)
+
 	return secretContent, nil
 }
 
+// GetSecretByID retrieves the secret content by its ID.
+func GetSecretByID(id string, peek bool, refresh bool) (map[string]string, error) {
+	return defaultClient.GetSecretByID(id, peek, refresh)
+}
+
 // GetSecretByLabel retrieves the secret content by its label.
-func GetSecretByLabel(label string, peek bool, refresh bool) (map[string]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetSecretByLabel(label string, peek bool, refresh bool) (map[string]string, error) {
+	commandRunner := c.commandRunner()
 
 	var args []string
 
@@ -162,13 +188,20 @@ func GetSecretByLabel(label string, peek bool, refresh bool) (map[string]string,
 		return nil, fmt.Errorf("failed to parse secret content: %w", err)
 	}
 
+	c.markSecretContent(secretContent)
+
 	return secretContent, nil
 }
 
+// GetSecretByLabel retrieves the secret content by its label.
+func GetSecretByLabel(label string, peek bool, refresh bool) (map[string]string, error) {
+	return defaultClient.GetSecretByLabel(label, peek, refresh)
+}
+
 // GrantSecretToRelation grants a secret to a specific relation.
 // All units of the related application are granted access
-func GrantSecretToRelation(id string, relation string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) GrantSecretToRelation(id string, relation string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id, "--relation=" + relation}
 
@@ -180,9 +213,15 @@ func GrantSecretToRelation(id string, relation string) error {
 	return nil
 }
 
+// GrantSecretToRelation grants a secret to a specific relation.
+// All units of the related application are granted access
+func GrantSecretToRelation(id string, relation string) error {
+	return defaultClient.GrantSecretToRelation(id, relation)
+}
+
 // GrantSecretToUnit grants a secret to a specific unit in a relation.
-func GrantSecretToUnit(id string, relation string, unit string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) GrantSecretToUnit(id string, relation string, unit string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id, "--relation=" + relation, "--unit=" + unit}
 
@@ -194,9 +233,14 @@ func GrantSecretToUnit(id string, relation string, unit string) error {
 	return nil
 }
 
+// GrantSecretToUnit grants a secret to a specific unit in a relation.
+func GrantSecretToUnit(id string, relation string, unit string) error {
+	return defaultClient.GrantSecretToUnit(id, relation, unit)
+}
+
 // GetSecretIDs retrieves the IDs for secrets owned by the application.
-func GetSecretIDs() ([]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetSecretIDs() ([]string, error) {
+	commandRunner := c.commandRunner()
 
 	output, err := commandRunner.Run(secredIDsCommand, "--format=json")
 	if err != nil {
@@ -213,9 +257,14 @@ func GetSecretIDs() ([]string, error) {
 	return secretIDs, nil
 }
 
+// GetSecretIDs retrieves the IDs for secrets owned by the application.
+func GetSecretIDs() ([]string, error) {
+	return defaultClient.GetSecretIDs()
+}
+
 // GetSecretInfoByID retrieves a secret metadata info by its ID.
-func GetSecretInfoByID(id string) (map[string]SecretInfo, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetSecretInfoByID(id string) (map[string]SecretInfo, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{}
 
@@ -242,9 +291,14 @@ func GetSecretInfoByID(id string) (map[string]SecretInfo, error) {
 	return secretInfo, nil
 }
 
+// GetSecretInfoByID retrieves a secret metadata info by its ID.
+func GetSecretInfoByID(id string) (map[string]SecretInfo, error) {
+	return defaultClient.GetSecretInfoByID(id)
+}
+
 // GetSecretInfoByLabel retrieves a secret metadata info by its label.
-func GetSecretInfoByLabel(label string) (map[string]SecretInfo, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetSecretInfoByLabel(label string) (map[string]SecretInfo, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{}
 
@@ -271,9 +325,14 @@ func GetSecretInfoByLabel(label string) (map[string]SecretInfo, error) {
 	return secretInfo, nil
 }
 
+// GetSecretInfoByLabel retrieves a secret metadata info by its label.
+func GetSecretInfoByLabel(label string) (map[string]SecretInfo, error) {
+	return defaultClient.GetSecretInfoByLabel(label)
+}
+
 // RemoveSecret removes a secret by its ID.
-func RemoveSecret(id string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) RemoveSecret(id string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id}
 
@@ -285,9 +344,14 @@ func RemoveSecret(id string) error {
 	return nil
 }
 
+// RemoveSecret removes a secret by its ID.
+func RemoveSecret(id string) error {
+	return defaultClient.RemoveSecret(id)
+}
+
 // RevokeSecret revokes a secret by its ID.
-func RevokeSecret(id string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) RevokeSecret(id string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id}
 
@@ -299,9 +363,14 @@ func RevokeSecret(id string) error {
 	return nil
 }
 
+// RevokeSecret revokes a secret by its ID.
+func RevokeSecret(id string) error {
+	return defaultClient.RevokeSecret(id)
+}
+
 // RevokeSecretFromRelation revokes a secret from a specific relation.
-func RevokeSecretFromRelation(id string, relation string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) RevokeSecretFromRelation(id string, relation string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id}
 
@@ -315,9 +384,14 @@ func RevokeSecretFromRelation(id string, relation string) error {
 	return nil
 }
 
+// RevokeSecretFromRelation revokes a secret from a specific relation.
+func RevokeSecretFromRelation(id string, relation string) error {
+	return defaultClient.RevokeSecretFromRelation(id, relation)
+}
+
 // RevokeSecretFromApp revokes a secret from a specific application.
-func RevokeSecretFromApp(id string, app string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) RevokeSecretFromApp(id string, app string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id}
 
@@ -332,8 +406,13 @@ func RevokeSecretFromApp(id string, app string) error {
 }
 
 // RevokeSecretFromApp revokes a secret from a specific application.
-func RevokeSecretFromUnit(id string, unit string) error {
-	commandRunner := GetCommandRunner()
+func RevokeSecretFromApp(id string, app string) error {
+	return defaultClient.RevokeSecretFromApp(id, app)
+}
+
+// RevokeSecretFromApp revokes a secret from a specific application.
+func (c *Client) RevokeSecretFromUnit(id string, unit string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{id}
 
@@ -347,9 +426,14 @@ func RevokeSecretFromUnit(id string, unit string) error {
 	return nil
 }
 
+// RevokeSecretFromApp revokes a secret from a specific application.
+func RevokeSecretFromUnit(id string, unit string) error {
+	return defaultClient.RevokeSecretFromUnit(id, unit)
+}
+
 // SetSecret updates an existing secret with new content and options.
-func SetSecret(opts *SetSecretOptions) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetSecret(opts *SetSecretOptions) error {
+	commandRunner := c.commandRunner()
 
 	if opts.ID == "" {
 		return fmt.Errorf("secret ID cannot be empty")
@@ -357,8 +441,19 @@ func SetSecret(opts *SetSecretOptions) error {
 
 	args := []string{opts.ID}
 
-	for key, value := range opts.Content {
-		args = append(args, key+"="+value)
+	if len(opts.Content) > 0 || len(opts.BinaryContent) > 0 {
+		fileContent := secretFileContent(opts.Content, opts.BinaryContent)
+
+		c.markSecretContent(fileContent)
+		c.markBinarySecretContent(opts.BinaryContent)
+
+		contentFile, removeContentFile, err := writeContentFile(fileContent)
+		if err != nil {
+			return fmt.Errorf("failed to set secret: %w", err)
+		}
+		defer removeContentFile()
+
+		args = append(args, "--file="+contentFile)
 	}
 
 	if opts.Description != "" {
@@ -388,3 +483,22 @@ func SetSecret(opts *SetSecretOptions) error {
 
 	return nil
 }
+
+// SetSecret updates an existing secret with new content and options.
+func SetSecret(opts *SetSecretOptions) error {
+	return defaultClient.SetSecret(opts)
+}
+
+// markSecretContent marks the values of secret content as sensitive.
+func (c *Client) markSecretContent(content map[string]string) {
+	for _, value := range content {
+		c.sensitive.add(value)
+	}
+}
+
+// markBinarySecretContent marks the values of binary secret content as sensitive.
+func (c *Client) markBinarySecretContent(content map[string][]byte) {
+	for _, value := range content {
+		c.sensitive.add(string(value))
+	}
+}
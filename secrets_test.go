@@ -1,6 +1,8 @@
 package goops_test
 
 import (
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -134,33 +136,37 @@ func TestSecretAdd_Success(t *testing.T) {
 		t.Errorf("Expected command %q, got %q", "secret-add", fakeRunner.Command)
 	}
 
-	if len(fakeRunner.Args) != 6 {
-		t.Fatalf("Expected 6 arguments, got %d", len(fakeRunner.Args))
+	if len(fakeRunner.Args) != 5 {
+		t.Fatalf("Expected 5 arguments, got %d", len(fakeRunner.Args))
 	}
 
-	contentArg := fakeRunner.Args[0]
-	if fakeRunner.Args[0] != "username=user1" && fakeRunner.Args[1] != "username=user1" {
-		t.Errorf("Expected content arg %q, got %q", "username=user1", contentArg)
+	if !strings.HasPrefix(fakeRunner.Args[0], "--file=") {
+		t.Errorf("Expected content file arg, got %q", fakeRunner.Args[0])
 	}
 
-	if fakeRunner.Args[0] != "password=pass1" && fakeRunner.Args[1] != "password=pass1" {
-		t.Errorf("Expected content arg %q, got %q", "password=pass1", contentArg)
+	expectedContent := "password: pass1\nusername: user1\n"
+	if string(fakeRunner.FileContent) != expectedContent {
+		t.Errorf("Expected content file %q, got %q", expectedContent, string(fakeRunner.FileContent))
 	}
 
-	if fakeRunner.Args[2] != "--description=my secret" {
-		t.Errorf("Expected description arg %q, got %q", "--description=my secret", fakeRunner.Args[2])
+	if _, err := os.Stat(strings.TrimPrefix(fakeRunner.Args[0], "--file=")); !os.IsNotExist(err) {
+		t.Errorf("Expected content file to be removed")
 	}
 
-	if fakeRunner.Args[3] != "--label=my-label" {
-		t.Errorf("Expected label arg %q, got %q", "--label=my-label", fakeRunner.Args[3])
+	if fakeRunner.Args[1] != "--description=my secret" {
+		t.Errorf("Expected description arg %q, got %q", "--description=my secret", fakeRunner.Args[1])
 	}
 
-	if fakeRunner.Args[4] != "--rotate=never" {
-		t.Errorf("Expected rotate arg %q, got %q", "--rotate=never", fakeRunner.Args[4])
+	if fakeRunner.Args[2] != "--label=my-label" {
+		t.Errorf("Expected label arg %q, got %q", "--label=my-label", fakeRunner.Args[2])
 	}
 
-	if fakeRunner.Args[5] != "--expire="+expiry.Format(time.RFC3339) {
-		t.Errorf("Expected expire arg %q, got %q", "--expire="+expiry.Format(time.RFC3339), fakeRunner.Args[5])
+	if fakeRunner.Args[3] != "--rotate=never" {
+		t.Errorf("Expected rotate arg %q, got %q", "--rotate=never", fakeRunner.Args[3])
+	}
+
+	if fakeRunner.Args[4] != "--expire="+expiry.Format(time.RFC3339) {
+		t.Errorf("Expected expire arg %q, got %q", "--expire="+expiry.Format(time.RFC3339), fakeRunner.Args[4])
 	}
 }
 
@@ -185,6 +191,27 @@ func TestSecretAdd_EmptyContent(t *testing.T) {
 	}
 }
 
+func TestSecretAdd_BinaryContent(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`secret:123`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	_, err := client.AddSecret(&goops.AddSecretOptions{
+		Content:       map[string]string{"username": "user1"},
+		BinaryContent: map[string][]byte{"keystore": {0x00, 0xff, 0x10}},
+	})
+	if err != nil {
+		t.Fatalf("SecretAdd returned an error: %v", err)
+	}
+
+	expectedContent := "keystore#base64: AP8Q\nusername: user1\n"
+	if string(fakeRunner.FileContent) != expectedContent {
+		t.Errorf("Expected content file %q, got %q", expectedContent, string(fakeRunner.FileContent))
+	}
+}
+
 func TestSecretGrant_Success(t *testing.T) {
 	fakeRunner := &FakeRunner{
 		Output: []byte(`{"result":"success"}`),
@@ -344,27 +371,28 @@ func TestSecretSet_Success(t *testing.T) {
 		t.Fatalf("couldn't set secret: %v", err)
 	}
 
-	if len(fakeRunner.Args) != 6 {
-		t.Fatalf("Expected 6 arguments, got %d", len(fakeRunner.Args))
+	if len(fakeRunner.Args) != 5 {
+		t.Fatalf("Expected 5 arguments, got %d", len(fakeRunner.Args))
 	}
 
-	if fakeRunner.Args[1] != "username=user1" && fakeRunner.Args[2] != "username=user1" {
-		t.Errorf("Expected ID arg %q", "username=user1")
+	if !strings.HasPrefix(fakeRunner.Args[1], "--file=") {
+		t.Errorf("Expected content file arg, got %q", fakeRunner.Args[1])
 	}
 
-	if fakeRunner.Args[1] != "password=pass1" && fakeRunner.Args[2] != "password=pass1" {
-		t.Errorf("Expected ID arg %q", "password=pass1")
+	expectedContent := "password: pass1\nusername: user1\n"
+	if string(fakeRunner.FileContent) != expectedContent {
+		t.Errorf("Expected content file %q, got %q", expectedContent, string(fakeRunner.FileContent))
 	}
 
-	if fakeRunner.Args[3] != "--label=my-label" {
-		t.Errorf("Expected ID arg %q, got %q", "--label=my-label", fakeRunner.Args[3])
+	if fakeRunner.Args[2] != "--label=my-label" {
+		t.Errorf("Expected ID arg %q, got %q", "--label=my-label", fakeRunner.Args[2])
 	}
 
-	if fakeRunner.Args[4] != "--rotate=never" {
-		t.Errorf("Expected ID arg %q, got %q", "--rotate=never", fakeRunner.Args[4])
+	if fakeRunner.Args[3] != "--rotate=never" {
+		t.Errorf("Expected ID arg %q, got %q", "--rotate=never", fakeRunner.Args[3])
 	}
 
-	if fakeRunner.Args[5] != "--expire="+expiry.Format(time.RFC3339) {
-		t.Errorf("Expected ID arg %q, got %q", "--expire="+expiry.Format(time.RFC3339), fakeRunner.Args[5])
+	if fakeRunner.Args[4] != "--expire="+expiry.Format(time.RFC3339) {
+		t.Errorf("Expected ID arg %q, got %q", "--expire="+expiry.Format(time.RFC3339), fakeRunner.Args[4])
 	}
 }
@@ -0,0 +1,170 @@
+package goops
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sensitiveValues holds the values that must not appear in errors or logs.
+type sensitiveValues struct {
+	mu     sync.Mutex
+	values map[string]struct{}
+}
+
+func (s *sensitiveValues) add(values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.values == nil {
+		s.values = make(map[string]struct{})
+	}
+
+	for _, value := range values {
+		if value != "" {
+			s.values[value] = struct{}{}
+		}
+	}
+}
+
+func (s *sensitiveValues) contains(value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.values[value]
+
+	return ok
+}
+
+// redact replaces every sensitive value in text, longest values first so that
+// values containing other values are fully replaced. Values are only replaced
+// where they form a whole token, so that a short value such as "admin" does not
+// mangle "administrator".
+func (s *sensitiveValues) redact(text string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.values) == 0 {
+		return text
+	}
+
+	values := make([]string, 0, len(s.values))
+	for value := range s.values {
+		values = append(values, value)
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	for _, value := range values {
+		text = replaceTokens(text, value, redacted)
+	}
+
+	return text
+}
+
+// replaceTokens replaces the occurrences of old in text that are not directly preceded
+// or followed by a letter, digit or underscore continuing the token.
+func replaceTokens(text string, old string, replacement string) string {
+	var builder strings.Builder
+
+	for {
+		index := strings.Index(text, old)
+		if index < 0 {
+			builder.WriteString(text)
+
+			return builder.String()
+		}
+
+		end := index + len(old)
+
+		builder.WriteString(text[:index])
+
+		if startsToken(text[:index], old) && endsToken(old, text[end:]) {
+			builder.WriteString(replacement)
+		} else {
+			builder.WriteString(old)
+		}
+
+		text = text[end:]
+	}
+}
+
+// startsToken reports whether value starts a token when it follows before.
+func startsToken(before string, value string) bool {
+	first, _ := utf8.DecodeRuneInString(value)
+	last, _ := utf8.DecodeLastRuneInString(before)
+
+	return before == "" || !isWordRune(first) || !isWordRune(last)
+}
+
+// endsToken reports whether value ends a token when after follows it.
+func endsToken(value string, after string) bool {
+	last, _ := utf8.DecodeLastRuneInString(value)
+	first, _ := utf8.DecodeRuneInString(after)
+
+	return after == "" || !isWordRune(last) || !isWordRune(first)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// MarkSensitive records values that must not appear in the errors returned by
+// the hook tool wrappers or in the messages written with juju-log.
+// Secret content read or written through the client is marked automatically.
+func (c *Client) MarkSensitive(values ...string) {
+	c.sensitive.add(values...)
+}
+
+// MarkSensitive records values that must not appear in the errors returned by
+// the hook tool wrappers or in the messages written with juju-log.
+// Secret content read or written through the client is marked automatically.
+func MarkSensitive(values ...string) {
+	defaultClient.MarkSensitive(values...)
+}
+
+// redactingCommandRunner redacts sensitive values from the hook tool errors of runner.
+type redactingCommandRunner struct {
+	runner    CommandRunner
+	sensitive *sensitiveValues
+}
+
+func (r *redactingCommandRunner) Run(name string, args ...string) ([]byte, error) {
+	output, err := r.runner.Run(name, args...)
+	if err == nil {
+		return output, nil
+	}
+
+	var hookToolErr *HookToolError
+	if !errors.As(err, &hookToolErr) {
+		return output, err
+	}
+
+	redactedErr := *hookToolErr
+	redactedErr.Stderr = r.sensitive.redact(hookToolErr.Stderr)
+	redactedErr.Args = make([]string, len(hookToolErr.Args))
+
+	for i, arg := range hookToolErr.Args {
+		redactedErr.Args[i] = r.sensitive.redact(arg)
+	}
+
+	return output, &redactedErr
+}
+
+// commandRunner returns the runner used by the hook tool wrappers, which rejects calls
+// needing features the Juju agent lacks and redacts sensitive values from the errors
+// of the client's runner.
+func (c *Client) commandRunner() CommandRunner {
+	return &redactingCommandRunner{
+		runner: &featureCheckingCommandRunner{
+			runner: c.runner,
+			client: c,
+		},
+		sensitive: c.sensitive,
+	}
+}
@@ -0,0 +1,123 @@
+package goops_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestMarkSensitive_RedactsHookToolErrors(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Err: goops.NewHookToolError("relation-set", []string{"-r", "certificates:0", "password=hunter2"}, 1, "ERROR invalid value hunter2", nil),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+	client.MarkSensitive("hunter2")
+
+	err := client.SetUnitRelationData("certificates:0", map[string]string{"password": "hunter2"})
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Expected sensitive value to be redacted from %q", err.Error())
+	}
+
+	var hookToolErr *goops.HookToolError
+	if !errors.As(err, &hookToolErr) {
+		t.Fatalf("Expected a HookToolError, got %T", err)
+	}
+
+	if hookToolErr.Args[2] != "password=<redacted>" {
+		t.Errorf("Expected argument %q, got %q", "password=<redacted>", hookToolErr.Args[2])
+	}
+}
+
+func TestMarkSensitive_RedactsLogs(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+	client.MarkSensitive("hunter2")
+
+	client.LogInfof("password is %s", "hunter2")
+
+	if fakeRunner.Args[1] != "password is <redacted>" {
+		t.Errorf("Expected message %q, got %q", "password is <redacted>", fakeRunner.Args[1])
+	}
+}
+
+func TestGetSecretByID_MarksContentSensitive(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"password":"hunter2"}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	content, err := client.GetSecretByID("secret:123", false, false)
+	if err != nil {
+		t.Fatalf("GetSecretByID returned an error: %v", err)
+	}
+
+	client.LogDebugf("connecting with %s", content["password"])
+
+	if fakeRunner.Args[1] != "connecting with <redacted>" {
+		t.Errorf("Expected message %q, got %q", "connecting with <redacted>", fakeRunner.Args[1])
+	}
+}
+
+func TestGetSecretByID_ShortValuesRedactedAsWholeTokens(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"username":"admin","port":"5432","tls":"true","password":"hunter2"}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	_, err := client.GetSecretByID("secret:123", false, false)
+	if err != nil {
+		t.Fatalf("GetSecretByID returned an error: %v", err)
+	}
+
+	client.LogInfof("administrator set tls_enabled on port 54321")
+
+	if fakeRunner.Args[1] != "administrator set tls_enabled on port 54321" {
+		t.Errorf("Expected message %q, got %q", "administrator set tls_enabled on port 54321", fakeRunner.Args[1])
+	}
+
+	client.LogInfof("connecting as admin:hunter2 on port 5432")
+
+	if fakeRunner.Args[1] != "connecting as <redacted>:<redacted> on port <redacted>" {
+		t.Errorf("Expected message %q, got %q", "connecting as <redacted>:<redacted> on port <redacted>", fakeRunner.Args[1])
+	}
+}
+
+func TestGetSecretByID_ShortValuesWrittenThroughFile(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"password":"pw"}`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	content, err := client.GetSecretByID("secret:123", false, false)
+	if err != nil {
+		t.Fatalf("GetSecretByID returned an error: %v", err)
+	}
+
+	fakeRunner.Output = nil
+
+	err = client.SetUnitRelationData("db:0", map[string]string{"password": content["password"]})
+	if err != nil {
+		t.Fatalf("SetUnitRelationData returned an error: %v", err)
+	}
+
+	for _, arg := range fakeRunner.Args {
+		if strings.Contains(arg, "pw") {
+			t.Errorf("Expected short secret value to be kept out of arguments, got %v", fakeRunner.Args)
+		}
+	}
+
+	if string(fakeRunner.FileContent) != "password: pw\n" {
+		t.Errorf("Expected data file content %q, got %q", "password: pw\n", fakeRunner.FileContent)
+	}
+}
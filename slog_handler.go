@@ -0,0 +1,180 @@
+package goops
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LogRecord is a structured log record written through a SlogHandler.
+// Attrs maps attribute keys, prefixed with their dot-separated groups, to their rendered values.
+type LogRecord struct {
+	Level   Level
+	Message string
+	Attrs   map[string]string
+}
+
+type logAttr struct {
+	key   string
+	value string
+}
+
+// SlogHandler is a slog.Handler writing records with juju-log, so that they can be read using `juju debug-log`.
+// slog levels map to the closest juju-log level and attributes are appended to the
+// message as key=value pairs, with keys prefixed by their groups. When juju-log
+// cannot be run, for example outside of a hook, records are written to stderr instead.
+type SlogHandler struct {
+	client *Client
+	level  slog.Leveler
+	attrs  []logAttr
+	prefix string
+}
+
+// NewSlogHandler creates a slog.Handler logging through the client with juju-log.
+// Only the Level option is used. Records below Debug are dropped by default.
+func (c *Client) NewSlogHandler(opts *slog.HandlerOptions) *SlogHandler {
+	return newSlogHandler(c, opts)
+}
+
+// NewSlogHandler creates a slog.Handler logging with juju-log through the default client.
+// The default client is looked up for every record, so the handler keeps working
+// when it is replaced, as goopstest does.
+// Only the Level option is used. Records below Debug are dropped by default.
+func NewSlogHandler(opts *slog.HandlerOptions) *SlogHandler {
+	return newSlogHandler(nil, opts)
+}
+
+func newSlogHandler(client *Client, opts *slog.HandlerOptions) *SlogHandler {
+	h := &SlogHandler{
+		client: client,
+		level:  slog.LevelDebug,
+	}
+
+	if opts != nil && opts.Level != nil {
+		h.level = opts.Level
+	}
+
+	return h
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := append([]logAttr(nil), h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendLogAttr(attrs, h.prefix, attr)
+		return true
+	})
+
+	var builder strings.Builder
+
+	builder.WriteString(record.Message)
+
+	recordAttrs := make(map[string]string, len(attrs))
+
+	for _, attr := range attrs {
+		builder.WriteString(" " + attr.key + "=" + quoteLogValue(attr.value))
+
+		recordAttrs[attr.key] = attr.value
+	}
+
+	client := h.client
+	if client == nil {
+		client = defaultClient
+	}
+
+	level := slogLevel(record.Level)
+	message := builder.String()
+
+	err := client.jujuLog(level, message)
+	if err != nil {
+		_, err = fmt.Fprintf(os.Stderr, "%s %s\n", levelStrings[level], client.sensitive.redact(message))
+
+		return err
+	}
+
+	if client.onLogRecord != nil {
+		for key, value := range recordAttrs {
+			recordAttrs[key] = client.sensitive.redact(value)
+		}
+
+		client.onLogRecord(LogRecord{Level: level, Message: client.sensitive.redact(record.Message), Attrs: recordAttrs})
+	}
+
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = append([]logAttr(nil), h.attrs...)
+
+	for _, attr := range attrs {
+		handler.attrs = appendLogAttr(handler.attrs, h.prefix, attr)
+	}
+
+	return &handler
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	handler := *h
+	handler.prefix = h.prefix + name + "."
+
+	return &handler
+}
+
+// appendLogAttr appends attr, flattening groups into dot-separated keys.
+func appendLogAttr(attrs []logAttr, prefix string, attr slog.Attr) []logAttr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			attrs = appendLogAttr(attrs, groupPrefix, groupAttr)
+		}
+
+		return attrs
+	}
+
+	return append(attrs, logAttr{key: prefix + attr.Key, value: attr.Value.String()})
+}
+
+// quoteLogValue quotes values that would otherwise be ambiguous in a key=value list.
+func quoteLogValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// slogLevel returns the juju-log level closest to an slog level.
+func slogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return Debug
+	case level < slog.LevelWarn:
+		return Info
+	case level < slog.LevelError:
+		return Warning
+	default:
+		return Error
+	}
+}
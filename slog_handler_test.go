@@ -0,0 +1,109 @@
+package goops_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestSlogHandler_Levels(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		expected string
+	}{
+		{slog.LevelDebug, "--log-level=DEBUG"},
+		{slog.LevelInfo, "--log-level=INFO"},
+		{slog.LevelInfo + 2, "--log-level=INFO"},
+		{slog.LevelWarn, "--log-level=WARNING"},
+		{slog.LevelError, "--log-level=ERROR"},
+		{slog.LevelError + 4, "--log-level=ERROR"},
+	}
+
+	for _, tt := range tests {
+		fakeRunner := &FakeRunner{}
+		client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+		logger := slog.New(client.NewSlogHandler(nil))
+
+		logger.Log(t.Context(), tt.level, "my message")
+
+		if fakeRunner.Command != "juju-log" {
+			t.Fatalf("Expected command %q, got %q", "juju-log", fakeRunner.Command)
+		}
+
+		if fakeRunner.Args[0] != tt.expected {
+			t.Errorf("Expected argument %q for level %v, got %q", tt.expected, tt.level, fakeRunner.Args[0])
+		}
+	}
+}
+
+func TestSlogHandler_Attributes(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	var records []goops.LogRecord
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithLogRecordHandler(func(record goops.LogRecord) {
+			records = append(records, record)
+		}),
+	)
+
+	logger := slog.New(client.NewSlogHandler(nil)).With("unit", "example/0").WithGroup("request")
+
+	logger.Info("handled request", "path", "/api v1", slog.Group("response", "status", 200))
+
+	expectedMessage := `handled request unit=example/0 request.path="/api v1" request.response.status=200`
+	if fakeRunner.Args[1] != expectedMessage {
+		t.Errorf("Expected message %q, got %q", expectedMessage, fakeRunner.Args[1])
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+
+	if records[0].Level != goops.Info || records[0].Message != "handled request" {
+		t.Errorf("Unexpected record %+v", records[0])
+	}
+
+	if records[0].Attrs["request.response.status"] != "200" {
+		t.Errorf("Expected attribute %q to be %q, got %q", "request.response.status", "200", records[0].Attrs["request.response.status"])
+	}
+}
+
+func TestSlogHandler_LevelOption(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+	logger := slog.New(client.NewSlogHandler(&slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	logger.Info("ignored")
+
+	if fakeRunner.Command != "" {
+		t.Errorf("Expected no command to be run, got %q", fakeRunner.Command)
+	}
+}
+
+func TestSlogHandler_FallbackToStderr(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Err: errors.New("juju-log not found"),
+	}
+
+	var records []goops.LogRecord
+
+	client := goops.NewClient(
+		goops.WithCommandRunner(fakeRunner),
+		goops.WithLogRecordHandler(func(record goops.LogRecord) {
+			records = append(records, record)
+		}),
+	)
+
+	handler := client.NewSlogHandler(nil)
+
+	logger := slog.New(handler)
+	logger.Error("outside of a hook")
+
+	if len(records) != 0 {
+		t.Errorf("Expected no record to be reported when juju-log fails, got %d", len(records))
+	}
+}
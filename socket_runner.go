@@ -0,0 +1,146 @@
+package goops
+
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"os"
+	"sync"
+)
+
+// jujucRequest is the request sent to the unit agent to run a hook tool.
+// Field names must match the ones of the agent, as the jujuc protocol uses net/rpc with gob encoding.
+type jujucRequest struct {
+	ContextId   string
+	Dir         string
+	CommandName string
+	Args        []string
+	StdinSet    bool
+	Stdin       []byte
+	Token       string
+}
+
+// jujucResponse is the result of a hook tool run by the unit agent.
+type jujucResponse struct {
+	Code   int
+	Stdout []byte
+	Stderr []byte
+}
+
+const jujucMethod = "Jujuc.Main"
+
+// socketCommandRunner runs hook tools by calling the unit agent over its jujuc
+// socket, instead of executing the hook tool binaries, which all forward their
+// arguments over that same socket.
+type socketCommandRunner struct {
+	network   string
+	address   string
+	contextID string
+	token     string
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// NewSocketCommandRunner creates a CommandRunner that speaks the jujuc protocol
+// to the unit agent listening on network and address, for the hook context contextID.
+// token authenticates the requests and may be empty when the agent does not require it.
+// Clients created with NewClient use it automatically when JUJU_AGENT_SOCKET_NETWORK
+// is unix and JUJU_AGENT_SOCKET_ADDRESS and JUJU_CONTEXT_ID are set, taking the token
+// from JUJU_AGENT_TOKEN.
+func NewSocketCommandRunner(network string, address string, contextID string, token string) CommandRunner {
+	return &socketCommandRunner{
+		network:   network,
+		address:   address,
+		contextID: contextID,
+		token:     token,
+	}
+}
+
+func (r *socketCommandRunner) Run(name string, args ...string) ([]byte, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	request := jujucRequest{
+		ContextId:   r.contextID,
+		Dir:         dir,
+		CommandName: name,
+		Args:        args,
+		Token:       r.token,
+	}
+
+	var response jujucResponse
+
+	err = r.call(&request, &response)
+	if errors.Is(err, rpc.ErrShutdown) {
+		r.reset()
+
+		err = r.call(&request, &response)
+	}
+
+	if err != nil {
+		return nil, NewHookToolError(name, args, -1, "", fmt.Errorf("failed to call unit agent: %w", err))
+	}
+
+	if response.Code != 0 {
+		return nil, NewHookToolError(name, args, response.Code, string(response.Stderr), fmt.Errorf("exit status %d", response.Code))
+	}
+
+	return response.Stdout, nil
+}
+
+func (r *socketCommandRunner) call(request *jujucRequest, response *jujucResponse) error {
+	client, err := r.connect()
+	if err != nil {
+		return err
+	}
+
+	return client.Call(jujucMethod, request, response)
+}
+
+// connect returns the connection to the unit agent, dialing it on first use.
+// The connection is reused for the following hook tool calls.
+func (r *socketCommandRunner) connect() (*rpc.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil {
+		return r.client, nil
+	}
+
+	client, err := rpc.Dial(r.network, r.address)
+	if err != nil {
+		return nil, err
+	}
+
+	r.client = client
+
+	return client, nil
+}
+
+func (r *socketCommandRunner) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil {
+		_ = r.client.Close()
+		r.client = nil
+	}
+}
+
+// newDefaultCommandRunner returns the socket runner when the hook environment
+// exposes a unix jujuc socket and falls back to executing the hook tools otherwise.
+func newDefaultCommandRunner(envGetter EnvironmentGetter) CommandRunner {
+	network := envGetter.Get("JUJU_AGENT_SOCKET_NETWORK")
+	address := envGetter.Get("JUJU_AGENT_SOCKET_ADDRESS")
+	contextID := envGetter.Get("JUJU_CONTEXT_ID")
+	token := envGetter.Get("JUJU_AGENT_TOKEN")
+
+	if network != "unix" || address == "" || contextID == "" {
+		return &realHookCommand{}
+	}
+
+	return NewSocketCommandRunner(network, address, contextID, token)
+}
@@ -0,0 +1,181 @@
+package goops_test
+
+import (
+	"errors"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+// JujucRequest and JujucResponse mirror the types of the Juju unit agent.
+type JujucRequest struct {
+	ContextId   string
+	Dir         string
+	CommandName string
+	Args        []string
+	StdinSet    bool
+	Stdin       []byte
+	Token       string
+}
+
+type JujucResponse struct {
+	Code   int
+	Stdout []byte
+	Stderr []byte
+}
+
+type FakeJujuc struct {
+	mu       sync.Mutex
+	Requests []JujucRequest
+	Response JujucResponse
+}
+
+func (f *FakeJujuc) Main(req JujucRequest, resp *JujucResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Requests = append(f.Requests, req)
+	*resp = f.Response
+
+	return nil
+}
+
+// startFakeJujucServer serves the jujuc protocol on a unix socket and returns its address.
+func startFakeJujucServer(t *testing.T, jujuc *FakeJujuc) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "jujuc")
+	if err != nil {
+		t.Fatalf("failed to create socket directory: %v", err)
+	}
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	address := filepath.Join(dir, "agent.socket")
+
+	listener, err := net.Listen("unix", address)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", address, err)
+	}
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := rpc.NewServer()
+
+	err = server.RegisterName("Jujuc", jujuc)
+	if err != nil {
+		t.Fatalf("failed to register fake jujuc: %v", err)
+	}
+
+	go server.Accept(listener)
+
+	return address
+}
+
+func TestSocketCommandRunner_Success(t *testing.T) {
+	jujuc := &FakeJujuc{
+		Response: JujucResponse{Stdout: []byte("true")},
+	}
+
+	address := startFakeJujucServer(t, jujuc)
+
+	runner := goops.NewSocketCommandRunner("unix", address, "example/0-install-123", "")
+
+	for range 2 {
+		output, err := runner.Run("is-leader", "--format=json")
+		if err != nil {
+			t.Fatalf("Run returned an error: %v", err)
+		}
+
+		if string(output) != "true" {
+			t.Errorf("Expected output %q, got %q", "true", output)
+		}
+	}
+
+	if len(jujuc.Requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(jujuc.Requests))
+	}
+
+	request := jujuc.Requests[0]
+
+	if request.ContextId != "example/0-install-123" {
+		t.Errorf("Expected context ID %q, got %q", "example/0-install-123", request.ContextId)
+	}
+
+	if request.CommandName != "is-leader" {
+		t.Errorf("Expected command %q, got %q", "is-leader", request.CommandName)
+	}
+
+	if len(request.Args) != 1 || request.Args[0] != "--format=json" {
+		t.Errorf("Expected args [--format=json], got %v", request.Args)
+	}
+
+	wd, _ := os.Getwd()
+	if request.Dir != wd {
+		t.Errorf("Expected dir %q, got %q", wd, request.Dir)
+	}
+}
+
+func TestSocketCommandRunner_Failure(t *testing.T) {
+	jujuc := &FakeJujuc{
+		Response: JujucResponse{Code: 1, Stderr: []byte("ERROR this unit is not the leader\n")},
+	}
+
+	address := startFakeJujucServer(t, jujuc)
+
+	runner := goops.NewSocketCommandRunner("unix", address, "example/0-install-123", "")
+
+	_, err := runner.Run("status-get", "--application")
+
+	var hookToolErr *goops.HookToolError
+	if !errors.As(err, &hookToolErr) {
+		t.Fatalf("Expected a HookToolError, got %v", err)
+	}
+
+	if hookToolErr.ExitCode != 1 || hookToolErr.Stderr != "ERROR this unit is not the leader" {
+		t.Errorf("Unexpected error: %+v", hookToolErr)
+	}
+
+	if !errors.Is(err, goops.ErrNotLeader) {
+		t.Errorf("Expected error to match %v, got %v", goops.ErrNotLeader, err)
+	}
+}
+
+func TestNewClient_SelectsSocketRunner(t *testing.T) {
+	jujuc := &FakeJujuc{
+		Response: JujucResponse{Stdout: []byte("true")},
+	}
+
+	address := startFakeJujucServer(t, jujuc)
+
+	client := goops.NewClient(goops.WithEnvGetter(&FakeEnvGetter{
+		Env: map[string]string{
+			"JUJU_AGENT_SOCKET_NETWORK": "unix",
+			"JUJU_AGENT_SOCKET_ADDRESS": address,
+			"JUJU_CONTEXT_ID":           "example/0-install-123",
+			"JUJU_AGENT_TOKEN":          "agent-token",
+		},
+	}))
+
+	isLeader, err := client.IsLeader()
+	if err != nil {
+		t.Fatalf("IsLeader returned an error: %v", err)
+	}
+
+	if !isLeader {
+		t.Errorf("Expected unit to be leader")
+	}
+
+	if len(jujuc.Requests) != 1 || jujuc.Requests[0].CommandName != "is-leader" {
+		t.Fatalf("Expected is-leader to be sent to the agent, got %+v", jujuc.Requests)
+	}
+
+	if jujuc.Requests[0].Token != "agent-token" {
+		t.Errorf("Expected token %q from the environment getter, got %q", "agent-token", jujuc.Requests[0].Token)
+	}
+}
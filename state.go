@@ -3,6 +3,8 @@ package goops
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 const (
@@ -12,8 +14,8 @@ const (
 )
 
 // DeleteState deletes a state key.
-func DeleteState(key string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) DeleteState(key string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{key}
 
@@ -29,9 +31,33 @@ func DeleteState(key string) error {
 	return nil
 }
 
+// DeleteState deletes a state key.
+func DeleteState(key string) error {
+	return defaultClient.DeleteState(key)
+}
+
+// GetState retrieves the value of a state key.
+func (c *Client) GetState(key string) (string, error) {
+	state, err := c.getState(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(state) == 0 {
+		return "", fmt.Errorf("%w for key: %s", ErrStateNotFound, key)
+	}
+
+	return state, nil
+}
+
 // GetState retrieves the value of a state key.
 func GetState(key string) (string, error) {
-	commandRunner := GetCommandRunner()
+	return defaultClient.GetState(key)
+}
+
+// getState retrieves the value of a state key, which is empty when the key is not set.
+func (c *Client) getState(key string) (string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{key, "--format=json"}
 
@@ -47,16 +73,12 @@ func GetState(key string) (string, error) {
 		return "", fmt.Errorf("failed to parse state: %w", err)
 	}
 
-	if len(state) == 0 {
-		return "", fmt.Errorf("no state found for key: %s", key)
-	}
-
 	return state, nil
 }
 
 // SetState sets a state key to a value.
-func SetState(key string, value string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetState(key string, value string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{key + "=" + value}
 
@@ -67,3 +89,173 @@ func SetState(key string, value string) error {
 
 	return nil
 }
+
+// SetState sets a state key to a value.
+func SetState(key string, value string) error {
+	return defaultClient.SetState(key, value)
+}
+
+// ListState retrieves every state key and its value.
+func (c *Client) ListState() (map[string]string, error) {
+	commandRunner := c.commandRunner()
+
+	args := []string{"--format=json"}
+
+	output, err := commandRunner.Run(stateGetCommand, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]string
+
+	err = json.Unmarshal(output, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	if state == nil {
+		state = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// ListState retrieves every state key and its value.
+func ListState() (map[string]string, error) {
+	return defaultClient.ListState()
+}
+
+// GetStateInto retrieves the value of a state key and JSON-decodes it into v.
+// It is the counterpart of SetStateFrom.
+func (c *Client) GetStateInto(key string, v any) error {
+	value, err := c.GetState(key)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal([]byte(value), v)
+	if err != nil {
+		return fmt.Errorf("failed to decode state key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GetStateInto retrieves the value of a state key and JSON-decodes it into v.
+// It is the counterpart of SetStateFrom.
+func GetStateInto(key string, v any) error {
+	return defaultClient.GetStateInto(key, v)
+}
+
+// GetStateAsWithClient retrieves the value of a state key through c and JSON-decodes it into a T.
+func GetStateAsWithClient[T any](c *Client, key string) (T, error) {
+	var v T
+
+	err := c.GetStateInto(key, &v)
+
+	return v, err
+}
+
+// GetStateAs retrieves the value of a state key and JSON-decodes it into a T.
+func GetStateAs[T any](key string) (T, error) {
+	return GetStateAsWithClient[T](defaultClient, key)
+}
+
+// SetStateFrom JSON-encodes v and stores it under a state key.
+func (c *Client) SetStateFrom(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode state key %s: %w", key, err)
+	}
+
+	return c.SetState(key, string(value))
+}
+
+// SetStateFrom JSON-encodes v and stores it under a state key.
+func SetStateFrom(key string, v any) error {
+	return defaultClient.SetStateFrom(key, v)
+}
+
+// StoredState gives a charm component its own namespace in the unit's stored state,
+// so that libraries sharing a charm do not collide on keys. Values are JSON-encoded.
+type StoredState struct {
+	client    *Client
+	namespace string
+}
+
+// namespaceEscaper escapes the key separator in namespace names, so that the keys
+// of namespace "a.b" cannot be mistaken for keys of namespace "a".
+var namespaceEscaper = strings.NewReplacer(`\`, `\\`, ".", `\.`)
+
+// NewStoredState returns the stored state namespace with the given name.
+// Namespaces can be nested by naming them with dots, for example "tls.ca";
+// the keys of a nested namespace are not listed by its parent.
+func (c *Client) NewStoredState(namespace string) *StoredState {
+	return &StoredState{
+		client:    c,
+		namespace: namespaceEscaper.Replace(namespace),
+	}
+}
+
+// NewStoredState returns the stored state namespace with the given name.
+// Namespaces can be nested by naming them with dots, for example "tls.ca";
+// the keys of a nested namespace are not listed by its parent.
+func NewStoredState(namespace string) *StoredState {
+	return defaultClient.NewStoredState(namespace)
+}
+
+func (s *StoredState) key(key string) string {
+	return s.namespace + "." + key
+}
+
+// Get decodes the value stored under key into v.
+// It returns false when the key is not set.
+func (s *StoredState) Get(key string, v any) (bool, error) {
+	value, err := s.client.getState(s.key(key))
+	if err != nil {
+		return false, err
+	}
+
+	if value == "" {
+		return false, nil
+	}
+
+	err = json.Unmarshal([]byte(value), v)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode state key %s: %w", s.key(key), err)
+	}
+
+	return true, nil
+}
+
+// Set stores the JSON encoding of v under key.
+func (s *StoredState) Set(key string, v any) error {
+	return s.client.SetStateFrom(s.key(key), v)
+}
+
+// Delete removes key from the namespace.
+func (s *StoredState) Delete(key string) error {
+	return s.client.DeleteState(s.key(key))
+}
+
+// Keys lists the keys set in the namespace, without the namespace prefix.
+func (s *StoredState) Keys() ([]string, error) {
+	state, err := s.client.ListState()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := s.namespace + "."
+
+	var keys []string
+
+	for key := range state {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			keys = append(keys, name)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
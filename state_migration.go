@@ -0,0 +1,129 @@
+package goops
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// StateSchemaVersionKey is the state key under which the schema version of the charm's stored state is kept.
+const StateSchemaVersionKey = "goops-schema-version"
+
+// Migration upgrades the charm's stored state to Version.
+// Migrate is given the client the migration runs on.
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(*Client) error
+}
+
+// GetStateSchemaVersion returns the schema version recorded in stored state, or 0 if none was recorded.
+func (c *Client) GetStateSchemaVersion() (int, error) {
+	value, err := c.getState(StateSchemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if value == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse state schema version %q: %w", value, err)
+	}
+
+	return version, nil
+}
+
+// GetStateSchemaVersion returns the schema version recorded in stored state, or 0 if none was recorded.
+func GetStateSchemaVersion() (int, error) {
+	return defaultClient.GetStateSchemaVersion()
+}
+
+// SetStateSchemaVersion records the schema version of the charm's stored state.
+func (c *Client) SetStateSchemaVersion(version int) error {
+	return c.SetState(StateSchemaVersionKey, strconv.Itoa(version))
+}
+
+// SetStateSchemaVersion records the schema version of the charm's stored state.
+func SetStateSchemaVersion(version int) error {
+	return defaultClient.SetStateSchemaVersion(version)
+}
+
+func sortMigrations(migrations []Migration) ([]Migration, error) {
+	sorted := append([]Migration(nil), migrations...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration version must be positive, got %d", migration.Version)
+		}
+
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	return sorted, nil
+}
+
+// MigrateState runs, in version order, the migrations newer than the schema version
+// recorded in stored state. The recorded version is updated after each successful
+// migration, so a failed upgrade resumes from the failing migration on the next hook.
+// It is a no-op when the stored state is already up to date, which makes it safe to
+// call on every hook rather than only in upgrade-charm.
+func (c *Client) MigrateState(migrations ...Migration) error {
+	sorted, err := sortMigrations(migrations)
+	if err != nil {
+		return err
+	}
+
+	current, err := c.GetStateSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range sorted {
+		if migration.Version <= current {
+			continue
+		}
+
+		c.LogInfof("Migrating stored state to version %d: %s", migration.Version, migration.Description)
+
+		err := migration.Migrate(c)
+		if err != nil {
+			return fmt.Errorf("failed to migrate stored state to version %d: %w", migration.Version, err)
+		}
+
+		err = c.SetStateSchemaVersion(migration.Version)
+		if err != nil {
+			return fmt.Errorf("failed to record stored state version %d: %w", migration.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// MigrateState runs, in version order, the migrations newer than the schema version
+// recorded in stored state. The recorded version is updated after each successful
+// migration, so a failed upgrade resumes from the failing migration on the next hook.
+// It is a no-op when the stored state is already up to date, which makes it safe to
+// call on every hook rather than only in upgrade-charm.
+func MigrateState(migrations ...Migration) error {
+	return defaultClient.MigrateState(migrations...)
+}
+
+// latestMigrationVersion returns the highest version of the given migrations, or 0 when there are none.
+func latestMigrationVersion(migrations []Migration) int {
+	latest := 0
+
+	for _, migration := range migrations {
+		latest = max(latest, migration.Version)
+	}
+
+	return latest
+}
@@ -0,0 +1,82 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestMigrateState_UpToDate(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`"2"`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	called := false
+
+	err := goops.MigrateState(goops.Migration{
+		Version: 2,
+		Migrate: func(*goops.Client) error {
+			called = true
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("MigrateState returned an error: %v", err)
+	}
+
+	if called {
+		t.Errorf("Expected migration not to run when state is up to date")
+	}
+
+	if fakeRunner.Command != "state-get" {
+		t.Errorf("Expected command %q, got %q", "state-get", fakeRunner.Command)
+	}
+
+	if fakeRunner.Args[0] != goops.StateSchemaVersionKey {
+		t.Errorf("Expected key %q, got %q", goops.StateSchemaVersionKey, fakeRunner.Args[0])
+	}
+}
+
+func TestMigrateState_DuplicateVersion(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`"0"`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	noop := func(*goops.Client) error { return nil }
+
+	err := goops.MigrateState(
+		goops.Migration{Version: 1, Migrate: noop},
+		goops.Migration{Version: 1, Migrate: noop},
+	)
+	if err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+
+	if err.Error() != "duplicate migration version 1" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestGetStateSchemaVersion_Unset(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`""`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	version, err := goops.GetStateSchemaVersion()
+	if err != nil {
+		t.Fatalf("GetStateSchemaVersion returned an error: %v", err)
+	}
+
+	if version != 0 {
+		t.Errorf("Expected version 0, got %d", version)
+	}
+}
@@ -65,3 +65,96 @@ func TestStateGet_Success(t *testing.T) {
 		t.Errorf("Expected state %q, got %q", "value", state)
 	}
 }
+
+func TestListState_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"a":"1","b":"2"}`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	state, err := goops.ListState()
+	if err != nil {
+		t.Fatalf("ListState returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "state-get" {
+		t.Errorf("Expected command %q, got %q", "state-get", fakeRunner.Command)
+	}
+
+	if len(fakeRunner.Args) != 1 || fakeRunner.Args[0] != "--format=json" {
+		t.Errorf("Expected only the format argument, got %v", fakeRunner.Args)
+	}
+
+	if len(state) != 2 || state["a"] != "1" || state["b"] != "2" {
+		t.Errorf("Unexpected state: %v", state)
+	}
+}
+
+type exampleState struct {
+	Revision int      `json:"revision"`
+	Peers    []string `json:"peers"`
+}
+
+func TestGetStateAs_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`"{\"revision\":3,\"peers\":[\"a/0\"]}"`),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	state, err := goops.GetStateAs[exampleState]("key")
+	if err != nil {
+		t.Fatalf("GetStateAs returned an error: %v", err)
+	}
+
+	if state.Revision != 3 || len(state.Peers) != 1 || state.Peers[0] != "a/0" {
+		t.Errorf("Unexpected state: %+v", state)
+	}
+}
+
+func TestGetStateAsWithClient_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`"{\"revision\":3,\"peers\":[\"a/0\"]}"`),
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	state, err := goops.GetStateAsWithClient[exampleState](client, "key")
+	if err != nil {
+		t.Fatalf("GetStateAsWithClient returned an error: %v", err)
+	}
+
+	if state.Revision != 3 || len(state.Peers) != 1 || state.Peers[0] != "a/0" {
+		t.Errorf("Unexpected state: %+v", state)
+	}
+
+	if fakeRunner.Command != "state-get" {
+		t.Errorf("Expected command %q, got %q", "state-get", fakeRunner.Command)
+	}
+}
+
+func TestSetStateFrom_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	goops.SetCommandRunner(fakeRunner)
+
+	err := goops.SetStateFrom("key", exampleState{Revision: 3})
+	if err != nil {
+		t.Fatalf("SetStateFrom returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "state-set" {
+		t.Errorf("Expected command %q, got %q", "state-set", fakeRunner.Command)
+	}
+
+	expected := `key={"revision":3,"peers":null}`
+	if len(fakeRunner.Args) != 1 || fakeRunner.Args[0] != expected {
+		t.Errorf("Expected argument %q, got %v", expected, fakeRunner.Args)
+	}
+}
@@ -13,6 +13,7 @@ const (
 	StatusWaiting     StatusName = "waiting"
 	StatusMaintenance StatusName = "maintenance"
 	StatusUnknown     StatusName = "unknown"
+	StatusError       StatusName = "error" // Only used with AddUnitStatus and AddAppStatus, Juju does not accept it in status-set
 )
 
 const (
@@ -26,8 +27,8 @@ type UnitStatus struct {
 }
 
 // SetUnitStatus sets the unit status.
-func SetUnitStatus(status StatusName, message ...string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetUnitStatus(status StatusName, message ...string) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{string(status)}
 
@@ -52,10 +53,15 @@ func SetUnitStatus(status StatusName, message ...string) error {
 	return nil
 }
 
+// SetUnitStatus sets the unit status.
+func SetUnitStatus(status StatusName, message ...string) error {
+	return defaultClient.SetUnitStatus(status, message...)
+}
+
 // SetAppStatus sets the application status.
 // Only the leader unit can set the application status.
-func SetAppStatus(status StatusName, message ...string) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) SetAppStatus(status StatusName, message ...string) error {
+	commandRunner := c.commandRunner()
 
 	var args []string
 
@@ -73,9 +79,15 @@ func SetAppStatus(status StatusName, message ...string) error {
 	return nil
 }
 
+// SetAppStatus sets the application status.
+// Only the leader unit can set the application status.
+func SetAppStatus(status StatusName, message ...string) error {
+	return defaultClient.SetAppStatus(status, message...)
+}
+
 // GetUnitStatus returns the unit status information.
-func GetUnitStatus() (*UnitStatus, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetUnitStatus() (*UnitStatus, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--include-data", "--format=json"}
 
@@ -94,6 +106,11 @@ func GetUnitStatus() (*UnitStatus, error) {
 	return &status, nil
 }
 
+// GetUnitStatus returns the unit status information.
+func GetUnitStatus() (*UnitStatus, error) {
+	return defaultClient.GetUnitStatus()
+}
+
 type AppStatus struct {
 	Name    StatusName            `json:"status"`
 	Message string                `json:"message"`
@@ -106,8 +123,8 @@ type appStatusReturn struct {
 
 // GetAppStatus returns the application status information.
 // Only the leader unit can retrieve the application status.
-func GetAppStatus() (*AppStatus, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetAppStatus() (*AppStatus, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"--application", "--include-data", "--format=json"}
 
@@ -125,3 +142,9 @@ func GetAppStatus() (*AppStatus, error) {
 
 	return &status.AppStatus, nil
 }
+
+// GetAppStatus returns the application status information.
+// Only the leader unit can retrieve the application status.
+func GetAppStatus() (*AppStatus, error) {
+	return defaultClient.GetAppStatus()
+}
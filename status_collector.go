@@ -0,0 +1,149 @@
+package goops
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// statusPriorities orders statuses from least to most important when collecting candidates.
+var statusPriorities = map[StatusName]int{
+	StatusUnknown:     0,
+	StatusActive:      1,
+	StatusWaiting:     2,
+	StatusMaintenance: 3,
+	StatusBlocked:     4,
+	StatusError:       5,
+}
+
+// CollectedStatus is a candidate status added by a charm component during a hook.
+type CollectedStatus struct {
+	Name    StatusName
+	Message string
+}
+
+type statusCollector struct {
+	mu   sync.Mutex
+	unit []CollectedStatus
+	app  []CollectedStatus
+}
+
+// highestPriorityStatus returns the most important status, keeping the first one added on ties.
+func highestPriorityStatus(statuses []CollectedStatus) CollectedStatus {
+	highest := statuses[0]
+
+	for _, status := range statuses[1:] {
+		if statusPriorities[status.Name] > statusPriorities[highest.Name] {
+			highest = status
+		}
+	}
+
+	return highest
+}
+
+// AddUnitStatus adds a candidate unit status. Charms with several components
+// can let each of them add the status it needs, and call CommitStatus once at
+// the end of the hook to set the most important one. From most to least
+// important: error, blocked, maintenance, waiting, active.
+func (c *Client) AddUnitStatus(status StatusName, message ...string) {
+	c.statuses.mu.Lock()
+	defer c.statuses.mu.Unlock()
+
+	c.statuses.unit = append(c.statuses.unit, CollectedStatus{
+		Name:    status,
+		Message: strings.Join(message, " "),
+	})
+}
+
+// AddUnitStatus adds a candidate unit status. Charms with several components
+// can let each of them add the status it needs, and call CommitStatus once at
+// the end of the hook to set the most important one. From most to least
+// important: error, blocked, maintenance, waiting, active.
+func AddUnitStatus(status StatusName, message ...string) {
+	defaultClient.AddUnitStatus(status, message...)
+}
+
+// AddAppStatus adds a candidate application status.
+// It is only set by CommitStatus when the unit is the leader.
+func (c *Client) AddAppStatus(status StatusName, message ...string) {
+	c.statuses.mu.Lock()
+	defer c.statuses.mu.Unlock()
+
+	c.statuses.app = append(c.statuses.app, CollectedStatus{
+		Name:    status,
+		Message: strings.Join(message, " "),
+	})
+}
+
+// AddAppStatus adds a candidate application status.
+// It is only set by CommitStatus when the unit is the leader.
+func AddAppStatus(status StatusName, message ...string) {
+	defaultClient.AddAppStatus(status, message...)
+}
+
+// CollectedUnitStatuses returns the candidate unit statuses added during the hook.
+func (c *Client) CollectedUnitStatuses() []CollectedStatus {
+	c.statuses.mu.Lock()
+	defer c.statuses.mu.Unlock()
+
+	return append([]CollectedStatus(nil), c.statuses.unit...)
+}
+
+// CollectedAppStatuses returns the candidate application statuses added during the hook.
+func (c *Client) CollectedAppStatuses() []CollectedStatus {
+	c.statuses.mu.Lock()
+	defer c.statuses.mu.Unlock()
+
+	return append([]CollectedStatus(nil), c.statuses.app...)
+}
+
+// CommitStatus sets the most important candidate unit status and, on the leader,
+// the most important candidate application status.
+// An error status cannot be set through status-set, so an error candidate is
+// returned as an error instead, which puts the unit in error when the hook fails.
+func (c *Client) CommitStatus() error {
+	unitStatuses := c.CollectedUnitStatuses()
+	appStatuses := c.CollectedAppStatuses()
+
+	if len(unitStatuses) > 0 {
+		status := highestPriorityStatus(unitStatuses)
+
+		if status.Name == StatusError {
+			return fmt.Errorf("unit is in error: %s", status.Message)
+		}
+
+		err := c.SetUnitStatus(status.Name, status.Message)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(appStatuses) == 0 {
+		return nil
+	}
+
+	isLeader, err := c.IsLeader()
+	if err != nil {
+		return err
+	}
+
+	if !isLeader {
+		return nil
+	}
+
+	status := highestPriorityStatus(appStatuses)
+
+	if status.Name == StatusError {
+		return fmt.Errorf("application is in error: %s", status.Message)
+	}
+
+	return c.SetAppStatus(status.Name, status.Message)
+}
+
+// CommitStatus sets the most important candidate unit status and, on the leader,
+// the most important candidate application status.
+// An error status cannot be set through status-set, so an error candidate is
+// returned as an error instead, which puts the unit in error when the hook fails.
+func CommitStatus() error {
+	return defaultClient.CommitStatus()
+}
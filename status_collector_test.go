@@ -0,0 +1,75 @@
+package goops_test
+
+import (
+	"testing"
+
+	"github.com/gruyaume/goops"
+)
+
+func TestCommitStatus_HighestPriorityUnitStatus(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	client.AddUnitStatus(goops.StatusActive)
+	client.AddUnitStatus(goops.StatusWaiting, "waiting for database")
+	client.AddUnitStatus(goops.StatusBlocked, "missing config")
+	client.AddUnitStatus(goops.StatusMaintenance, "installing")
+	client.AddUnitStatus(goops.StatusBlocked, "missing relation")
+
+	err := client.CommitStatus()
+	if err != nil {
+		t.Fatalf("CommitStatus returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "status-set" {
+		t.Errorf("Expected command %q, got %q", "status-set", fakeRunner.Command)
+	}
+
+	if len(fakeRunner.Args) != 2 {
+		t.Fatalf("Expected 2 arguments, got %d", len(fakeRunner.Args))
+	}
+
+	if fakeRunner.Args[0] != "blocked" || fakeRunner.Args[1] != "missing config" {
+		t.Errorf("Expected status blocked with message %q, got %v", "missing config", fakeRunner.Args)
+	}
+}
+
+func TestCommitStatus_ErrorCandidate(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(``),
+		Err:    nil,
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	client.AddUnitStatus(goops.StatusBlocked, "missing config")
+	client.AddUnitStatus(goops.StatusError, "workload crashed")
+
+	err := client.CommitStatus()
+	if err == nil {
+		t.Fatalf("Expected an error, got nil")
+	}
+
+	if fakeRunner.Command != "" {
+		t.Errorf("Expected no command to be run, got %q", fakeRunner.Command)
+	}
+}
+
+func TestCommitStatus_NoCandidates(t *testing.T) {
+	fakeRunner := &FakeRunner{}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner))
+
+	err := client.CommitStatus()
+	if err != nil {
+		t.Fatalf("CommitStatus returned an error: %v", err)
+	}
+
+	if fakeRunner.Command != "" {
+		t.Errorf("Expected no command to be run, got %q", fakeRunner.Command)
+	}
+}
@@ -3,6 +3,7 @@ package goops
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 const (
@@ -12,8 +13,8 @@ const (
 )
 
 // AddStorage adds a storage instance to the unit.
-func AddStorage(name string, count int) error {
-	commandRunner := GetCommandRunner()
+func (c *Client) AddStorage(name string, count int) error {
+	commandRunner := c.commandRunner()
 
 	args := []string{}
 
@@ -27,14 +28,55 @@ func AddStorage(name string, count int) error {
 	return nil
 }
 
+// AddStorage adds a storage instance to the unit.
+func AddStorage(name string, count int) error {
+	return defaultClient.AddStorage(name, count)
+}
+
+type StorageKind string
+
+const (
+	StorageBlock      StorageKind = "block"
+	StorageFilesystem StorageKind = "filesystem"
+)
+
+// StorageInfo describes a storage instance attached to the unit.
+// ID is the storage instance ID, for example "data/0".
+// Location is the mount point of a filesystem, or the device path of a block device.
 type StorageInfo struct {
-	Kind     string `json:"kind"`
-	Location string `json:"location"`
+	ID       string      `json:"-"`
+	Kind     StorageKind `json:"kind"`
+	Location string      `json:"location"`
+}
+
+// Name returns the storage name of the instance, as declared in the charm metadata.
+func (s *StorageInfo) Name() string {
+	name, _, _ := strings.Cut(s.ID, "/")
+
+	return name
+}
+
+// FilesystemPath returns the mount point of a filesystem storage instance.
+func (s *StorageInfo) FilesystemPath() (string, error) {
+	if s.Kind != StorageFilesystem {
+		return "", fmt.Errorf("storage %s is of kind %q, not %q", s.ID, s.Kind, StorageFilesystem)
+	}
+
+	return s.Location, nil
+}
+
+// BlockDevicePath returns the device path of a block storage instance.
+func (s *StorageInfo) BlockDevicePath() (string, error) {
+	if s.Kind != StorageBlock {
+		return "", fmt.Errorf("storage %s is of kind %q, not %q", s.ID, s.Kind, StorageBlock)
+	}
+
+	return s.Location, nil
 }
 
 // GetStorageByID retrieves storage information by its ID.
-func GetStorageByID(id string) (*StorageInfo, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) GetStorageByID(id string) (*StorageInfo, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{"-s", id}
 
@@ -52,12 +94,38 @@ func GetStorageByID(id string) (*StorageInfo, error) {
 		return nil, fmt.Errorf("failed to parse storage: %w", err)
 	}
 
+	storageInfo.ID = id
+
 	return &storageInfo, nil
 }
 
+// GetStorageByID retrieves storage information by its ID.
+func GetStorageByID(id string) (*StorageInfo, error) {
+	return defaultClient.GetStorageByID(id)
+}
+
+// GetCurrentStorage retrieves the storage instance that triggered the current
+// storage-attached or storage-detaching hook, identified by JUJU_STORAGE_ID.
+// ErrNotStorageHook is returned in other hooks.
+func (c *Client) GetCurrentStorage() (*StorageInfo, error) {
+	id := c.envGetter.Get("JUJU_STORAGE_ID")
+	if id == "" {
+		return nil, ErrNotStorageHook
+	}
+
+	return c.GetStorageByID(id)
+}
+
+// GetCurrentStorage retrieves the storage instance that triggered the current
+// storage-attached or storage-detaching hook, identified by JUJU_STORAGE_ID.
+// ErrNotStorageHook is returned in other hooks.
+func GetCurrentStorage() (*StorageInfo, error) {
+	return defaultClient.GetCurrentStorage()
+}
+
 // ListStorage lists all storage IDs for a given storage name.
-func ListStorage(name string) ([]string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) ListStorage(name string) ([]string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{name, "--format=json"}
 
@@ -75,3 +143,8 @@ func ListStorage(name string) ([]string, error) {
 
 	return storageNames, nil
 }
+
+// ListStorage lists all storage IDs for a given storage name.
+func ListStorage(name string) ([]string, error) {
+	return defaultClient.ListStorage(name)
+}
@@ -1,6 +1,7 @@
 package goops_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gruyaume/goops"
@@ -141,3 +142,50 @@ func TestStorageList_Success(t *testing.T) {
 		t.Errorf("Expected storage item %q, got %q", "database-storage/1", storage[1])
 	}
 }
+
+func TestGetCurrentStorage_Success(t *testing.T) {
+	fakeRunner := &FakeRunner{
+		Output: []byte(`{"kind":"block","location":"/dev/sdb"}`),
+	}
+	fakeEnv := &FakeEnvGetter{
+		Env: map[string]string{"JUJU_STORAGE_ID": "data/1"},
+	}
+
+	client := goops.NewClient(goops.WithCommandRunner(fakeRunner), goops.WithEnvGetter(fakeEnv))
+
+	storage, err := client.GetCurrentStorage()
+	if err != nil {
+		t.Fatalf("GetCurrentStorage returned an error: %v", err)
+	}
+
+	if fakeRunner.Args[1] != "data/1" {
+		t.Errorf("Expected argument %q, got %q", "data/1", fakeRunner.Args[1])
+	}
+
+	if storage.ID != "data/1" || storage.Name() != "data" {
+		t.Errorf("Expected storage data/1 named data, got %s named %s", storage.ID, storage.Name())
+	}
+
+	path, err := storage.BlockDevicePath()
+	if err != nil {
+		t.Fatalf("BlockDevicePath returned an error: %v", err)
+	}
+
+	if path != "/dev/sdb" {
+		t.Errorf("Expected path %q, got %q", "/dev/sdb", path)
+	}
+
+	_, err = storage.FilesystemPath()
+	if err == nil {
+		t.Error("Expected FilesystemPath to fail for block storage")
+	}
+}
+
+func TestGetCurrentStorage_NotStorageHook(t *testing.T) {
+	client := goops.NewClient(goops.WithCommandRunner(&FakeRunner{}), goops.WithEnvGetter(&FakeEnvGetter{}))
+
+	_, err := client.GetCurrentStorage()
+	if !errors.Is(err, goops.ErrNotStorageHook) {
+		t.Errorf("Expected ErrNotStorageHook, got %v", err)
+	}
+}
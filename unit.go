@@ -9,8 +9,8 @@ const (
 	unitGetCommand = "unit-get"
 )
 
-func getUnit(key string) (string, error) {
-	commandRunner := GetCommandRunner()
+func (c *Client) getUnit(key string) (string, error) {
+	commandRunner := c.commandRunner()
 
 	args := []string{key}
 
@@ -29,12 +29,22 @@ func getUnit(key string) (string, error) {
 	return result, nil
 }
 
+// GetUnitName returns the public IP address of the unit.
+func (c *Client) GetUnitPublicAddress() (string, error) {
+	return c.getUnit("public-address")
+}
+
 // GetUnitName returns the public IP address of the unit.
 func GetUnitPublicAddress() (string, error) {
-	return getUnit("public-address")
+	return defaultClient.GetUnitPublicAddress()
+}
+
+// GetUnitPrivateAddress returns the private IP address of the unit.
+func (c *Client) GetUnitPrivateAddress() (string, error) {
+	return c.getUnit("private-address")
 }
 
 // GetUnitPrivateAddress returns the private IP address of the unit.
 func GetUnitPrivateAddress() (string, error) {
-	return getUnit("private-address")
+	return defaultClient.GetUnitPrivateAddress()
 }